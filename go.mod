@@ -2,9 +2,19 @@ module github.com/andreyea/tm1go
 
 go 1.21
 
-require github.com/go-gota/gota v0.12.0
+require (
+	github.com/go-gota/gota v0.12.0
+	github.com/jcmturner/gokrb5/v8 v8.4.4
+)
 
 require (
-	golang.org/x/net v0.0.0-20210423184538-5f58ad60dda6 // indirect
+	github.com/hashicorp/go-uuid v1.0.3 // indirect
+	github.com/jcmturner/aescts/v2 v2.0.0 // indirect
+	github.com/jcmturner/dnsutils/v2 v2.0.0 // indirect
+	github.com/jcmturner/gofork v1.7.6 // indirect
+	github.com/jcmturner/goidentity/v6 v6.0.1 // indirect
+	github.com/jcmturner/rpc/v2 v2.0.3 // indirect
+	golang.org/x/crypto v0.10.0 // indirect
+	golang.org/x/net v0.11.0 // indirect
 	gonum.org/v1/gonum v0.9.1 // indirect
 )
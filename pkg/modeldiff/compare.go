@@ -0,0 +1,436 @@
+package modeldiff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+// compareCubes compares cube dimensions, rules and drillthrough rules, and the public views of
+// cubes present on both servers.
+func (c *comparison) compareCubes() error {
+	sourceCubes, err := c.cubes(c.source.Cubes.GetAll, c.source.Cubes.GetModelCubes)
+	if err != nil {
+		return err
+	}
+	targetCubes, err := c.cubes(c.target.Cubes.GetAll, c.target.Cubes.GetModelCubes)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range c.add(ObjectCube, "", cubeNames(sourceCubes), cubeNames(targetCubes)) {
+		sourceCube, targetCube := sourceCubes[models.NormalizeName(pair[0])], targetCubes[models.NormalizeName(pair[1])]
+
+		var props properties
+		props.value("Dimensions", strings.Join(dimensionNames(sourceCube), ", "), strings.Join(dimensionNames(targetCube), ", "))
+		props.value("Rules", normalizeCode(sourceCube.Rules), normalizeCode(targetCube.Rules))
+		props.value("DrillthroughRules", normalizeCode(sourceCube.DrillthroughRules), normalizeCode(targetCube.DrillthroughRules))
+		c.modified(ObjectCube, pair[0], props)
+
+		if c.wants(ObjectView) {
+			if err := c.compareViews(pair[0], pair[1]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cubes returns the cubes of one server keyed by normalized name.
+func (c *comparison) cubes(getAll, getModelCubes func(ctx context.Context) ([]models.Cube, error)) (map[string]models.Cube, error) {
+	get := getModelCubes
+	if c.opts.IncludeControlObjects {
+		get = getAll
+	}
+	cubes, err := get(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.Cube, len(cubes))
+	for _, cube := range cubes {
+		byName[models.NormalizeName(cube.Name)] = cube
+	}
+	return byName, nil
+}
+
+// compareViews compares the public views of a cube by their MDX.
+func (c *comparison) compareViews(sourceCube, targetCube string) error {
+	sourceViews, err := c.source.Views.GetAll(c.ctx, sourceCube, false)
+	if err != nil {
+		return fmt.Errorf("get views of cube '%s': %w", sourceCube, err)
+	}
+	targetViews, err := c.target.Views.GetAll(c.ctx, targetCube, false)
+	if err != nil {
+		return fmt.Errorf("get views of cube '%s': %w", targetCube, err)
+	}
+
+	sourceByName, sourceNames := viewsByName(sourceViews)
+	targetByName, targetNames := viewsByName(targetViews)
+	for _, pair := range c.add(ObjectView, sourceCube+":", sourceNames, targetNames) {
+		sourceView, targetView := sourceByName[models.NormalizeName(pair[0])], targetByName[models.NormalizeName(pair[1])]
+		var props properties
+		props.value("Type", viewType(sourceView), viewType(targetView))
+		props.value("MDX", viewDefinition(sourceView), viewDefinition(targetView))
+		c.modified(ObjectView, sourceCube+":"+pair[0], props)
+	}
+	return nil
+}
+
+// compareDimensions compares which dimensions exist and, for dimensions present on both servers,
+// their hierarchies and public subsets.
+func (c *comparison) compareDimensions() error {
+	skipControl := !c.opts.IncludeControlObjects
+	sourceNames, err := c.source.Dimensions.GetAllNames(c.ctx, skipControl)
+	if err != nil {
+		return err
+	}
+	targetNames, err := c.target.Dimensions.GetAllNames(c.ctx, skipControl)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range c.add(ObjectDimension, "", sourceNames, targetNames) {
+		if !c.wants(ObjectHierarchy, ObjectSubset) {
+			break
+		}
+		if err := c.compareHierarchies(pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareHierarchies compares the hierarchies of a dimension present on both servers.
+func (c *comparison) compareHierarchies(sourceDimension, targetDimension string) error {
+	sourceNames, err := c.source.Hierarchies.GetAllNames(c.ctx, sourceDimension)
+	if err != nil {
+		return fmt.Errorf("get hierarchies of dimension '%s': %w", sourceDimension, err)
+	}
+	targetNames, err := c.target.Hierarchies.GetAllNames(c.ctx, targetDimension)
+	if err != nil {
+		return fmt.Errorf("get hierarchies of dimension '%s': %w", targetDimension, err)
+	}
+
+	for _, pair := range c.add(ObjectHierarchy, sourceDimension+":", withoutLeaves(sourceNames), withoutLeaves(targetNames)) {
+		name := sourceDimension + ":" + pair[0]
+		sourceHierarchy, err := c.source.Hierarchies.Get(c.ctx, sourceDimension, pair[0])
+		if err != nil {
+			return fmt.Errorf("get hierarchy '%s': %w", name, err)
+		}
+		targetHierarchy, err := c.target.Hierarchies.Get(c.ctx, targetDimension, pair[1])
+		if err != nil {
+			return fmt.Errorf("get hierarchy '%s': %w", name, err)
+		}
+
+		c.modified(ObjectHierarchy, name, hierarchyProperties(sourceHierarchy, targetHierarchy))
+
+		if c.wants(ObjectSubset) {
+			if err := c.compareSubsets(name, [2]string{sourceDimension, targetDimension}, pair, sourceHierarchy.Subsets, targetHierarchy.Subsets); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hierarchyProperties compares elements, element types, edges, weights and attributes.
+func hierarchyProperties(source, target *models.Hierarchy) properties {
+	var props properties
+
+	targetElements := elementsByName(target)
+	props.set("Elements", elementNames(source), elementNames(target))
+	for _, element := range source.Elements {
+		if other, ok := targetElements[models.NormalizeName(element.Name)]; ok {
+			props.value("Type of "+element.Name, string(element.Type), string(other.Type))
+		}
+	}
+
+	targetEdges := edgesByKey(target)
+	props.set("Edges", edgeNames(source), edgeNames(target))
+	for _, edge := range source.Edges {
+		if other, ok := targetEdges[edgeKey(edge)]; ok {
+			props.value("Weight of "+edgeName(edge), formatWeight(edge.Weight), formatWeight(other.Weight))
+		}
+	}
+
+	targetAttributes := make(map[string]models.ElementAttribute, len(target.ElementAttributes))
+	var sourceAttributeNames, targetAttributeNames []string
+	for _, attribute := range target.ElementAttributes {
+		targetAttributes[models.NormalizeName(attribute.Name)] = attribute
+		targetAttributeNames = append(targetAttributeNames, attribute.Name)
+	}
+	for _, attribute := range source.ElementAttributes {
+		sourceAttributeNames = append(sourceAttributeNames, attribute.Name)
+	}
+	props.set("Attributes", sourceAttributeNames, targetAttributeNames)
+	for _, attribute := range source.ElementAttributes {
+		if other, ok := targetAttributes[models.NormalizeName(attribute.Name)]; ok {
+			props.value("Type of attribute "+attribute.Name, string(attribute.AttributeType), string(other.AttributeType))
+		}
+	}
+
+	return props
+}
+
+// compareSubsets compares the public subsets of a hierarchy present on both servers. Dynamic subsets
+// are compared by expression, static subsets by their elements in order.
+func (c *comparison) compareSubsets(hierarchy string, dimensions, hierarchies [2]string, sourceSubsets, targetSubsets []models.Subset) error {
+	for _, pair := range c.add(ObjectSubset, hierarchy+":", subsetNames(sourceSubsets), subsetNames(targetSubsets)) {
+		name := hierarchy + ":" + pair[0]
+		sourceSubset, err := c.source.Subsets.Get(c.ctx, pair[0], dimensions[0], hierarchies[0], false)
+		if err != nil {
+			return fmt.Errorf("get subset '%s': %w", name, err)
+		}
+		targetSubset, err := c.target.Subsets.Get(c.ctx, pair[1], dimensions[1], hierarchies[1], false)
+		if err != nil {
+			return fmt.Errorf("get subset '%s': %w", name, err)
+		}
+
+		var props properties
+		props.value("Alias", sourceSubset.Alias, targetSubset.Alias)
+		props.value("Expression", normalizeCode(sourceSubset.Expression), normalizeCode(targetSubset.Expression))
+		props.value("Elements", strings.Join(sourceSubset.Elements, "\n"), strings.Join(targetSubset.Elements, "\n"))
+		c.modified(ObjectSubset, name, props)
+	}
+	return nil
+}
+
+// compareProcesses compares process code, parameters, variables and data source.
+func (c *comparison) compareProcesses() error {
+	skipControl := !c.opts.IncludeControlObjects
+	sourceProcesses, err := c.source.Processes.GetAll(c.ctx, skipControl)
+	if err != nil {
+		return err
+	}
+	targetProcesses, err := c.target.Processes.GetAll(c.ctx, skipControl)
+	if err != nil {
+		return err
+	}
+
+	sourceByName := make(map[string]*models.Process, len(sourceProcesses))
+	var sourceNames []string
+	for _, process := range sourceProcesses {
+		sourceByName[models.NormalizeName(process.Name)] = process
+		sourceNames = append(sourceNames, process.Name)
+	}
+	targetByName := make(map[string]*models.Process, len(targetProcesses))
+	var targetNames []string
+	for _, process := range targetProcesses {
+		targetByName[models.NormalizeName(process.Name)] = process
+		targetNames = append(targetNames, process.Name)
+	}
+
+	for _, pair := range c.add(ObjectProcess, "", sourceNames, targetNames) {
+		source, target := sourceByName[models.NormalizeName(pair[0])], targetByName[models.NormalizeName(pair[1])]
+		var props properties
+		props.value("Prolog", normalizeCode(source.PrologProcedure), normalizeCode(target.PrologProcedure))
+		props.value("Metadata", normalizeCode(source.MetadataProcedure), normalizeCode(target.MetadataProcedure))
+		props.value("Data", normalizeCode(source.DataProcedure), normalizeCode(target.DataProcedure))
+		props.value("Epilog", normalizeCode(source.EpilogProcedure), normalizeCode(target.EpilogProcedure))
+		props.value("Parameters", processParameters(source), processParameters(target))
+		props.value("Variables", processVariables(source), processVariables(target))
+		props.value("DataSource", processDataSource(source), processDataSource(target))
+		c.modified(ObjectProcess, pair[0], props)
+	}
+	return nil
+}
+
+// compareChores compares chore schedules and tasks.
+func (c *comparison) compareChores() error {
+	sourceChores, err := c.source.Chores.GetAll(c.ctx)
+	if err != nil {
+		return err
+	}
+	targetChores, err := c.target.Chores.GetAll(c.ctx)
+	if err != nil {
+		return err
+	}
+
+	sourceByName := make(map[string]*models.Chore, len(sourceChores))
+	var sourceNames []string
+	for _, chore := range sourceChores {
+		if !c.skip(chore.Name) {
+			sourceByName[models.NormalizeName(chore.Name)] = chore
+			sourceNames = append(sourceNames, chore.Name)
+		}
+	}
+	targetByName := make(map[string]*models.Chore, len(targetChores))
+	var targetNames []string
+	for _, chore := range targetChores {
+		if !c.skip(chore.Name) {
+			targetByName[models.NormalizeName(chore.Name)] = chore
+			targetNames = append(targetNames, chore.Name)
+		}
+	}
+
+	for _, pair := range c.add(ObjectChore, "", sourceNames, targetNames) {
+		source, target := sourceByName[models.NormalizeName(pair[0])], targetByName[models.NormalizeName(pair[1])]
+		var props properties
+		props.value("StartTime", source.StartTime, target.StartTime)
+		props.value("Frequency", source.Frequency, target.Frequency)
+		props.value("ExecutionMode", source.ExecutionMode, target.ExecutionMode)
+		props.value("Active", strconv.FormatBool(source.Active), strconv.FormatBool(target.Active))
+		props.value("DSTSensitive", strconv.FormatBool(source.DSTSensitive), strconv.FormatBool(target.DSTSensitive))
+		props.value("Tasks", choreTasks(source), choreTasks(target))
+		c.modified(ObjectChore, pair[0], props)
+	}
+	return nil
+}
+
+func cubeNames(cubes map[string]models.Cube) []string {
+	names := make([]string, 0, len(cubes))
+	for _, cube := range cubes {
+		names = append(names, cube.Name)
+	}
+	return names
+}
+
+func dimensionNames(cube models.Cube) []string {
+	names := make([]string, len(cube.Dimensions))
+	for i, dimension := range cube.Dimensions {
+		names[i] = dimension.Name
+	}
+	return names
+}
+
+func viewsByName(views []models.ViewDefinition) (map[string]models.ViewDefinition, []string) {
+	byName := make(map[string]models.ViewDefinition, len(views))
+	names := make([]string, 0, len(views))
+	for _, view := range views {
+		byName[models.NormalizeName(view.GetName())] = view
+		names = append(names, view.GetName())
+	}
+	return byName, names
+}
+
+// viewType returns "Native" or "MDX"
+func viewType(view models.ViewDefinition) string {
+	if _, ok := view.(*models.MDXView); ok {
+		return "MDX"
+	}
+	return "Native"
+}
+
+// viewDefinition returns the MDX of a view, or its request body if a native view cannot be
+// expressed as MDX.
+func viewDefinition(view models.ViewDefinition) string {
+	switch v := view.(type) {
+	case *models.MDXView:
+		return normalizeCode(v.MDX)
+	case *models.NativeView:
+		if mdx, err := v.ToMDX(); err == nil {
+			return normalizeCode(mdx)
+		}
+	}
+	body, _ := view.Body(false)
+	return body
+}
+
+// withoutLeaves drops the Leaves hierarchy, which TM1 maintains itself
+func withoutLeaves(names []string) []string {
+	filtered := make([]string, 0, len(names))
+	for _, name := range names {
+		if models.NormalizeName(name) != "leaves" {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
+
+func elementsByName(hierarchy *models.Hierarchy) map[string]models.Element {
+	byName := make(map[string]models.Element, len(hierarchy.Elements))
+	for _, element := range hierarchy.Elements {
+		byName[models.NormalizeName(element.Name)] = element
+	}
+	return byName
+}
+
+func elementNames(hierarchy *models.Hierarchy) []string {
+	names := make([]string, len(hierarchy.Elements))
+	for i, element := range hierarchy.Elements {
+		names[i] = element.Name
+	}
+	return names
+}
+
+func edgesByKey(hierarchy *models.Hierarchy) map[string]models.Edge {
+	byKey := make(map[string]models.Edge, len(hierarchy.Edges))
+	for _, edge := range hierarchy.Edges {
+		byKey[edgeKey(edge)] = edge
+	}
+	return byKey
+}
+
+func edgeNames(hierarchy *models.Hierarchy) []string {
+	names := make([]string, len(hierarchy.Edges))
+	for i, edge := range hierarchy.Edges {
+		names[i] = edgeName(edge)
+	}
+	return names
+}
+
+func edgeName(edge models.Edge) string {
+	return edge.ParentName + " -> " + edge.ComponentName
+}
+
+func edgeKey(edge models.Edge) string {
+	return models.NormalizeName(edge.ParentName) + "\x00" + models.NormalizeName(edge.ComponentName)
+}
+
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'g', -1, 64)
+}
+
+func subsetNames(subsets []models.Subset) []string {
+	names := make([]string, len(subsets))
+	for i, subset := range subsets {
+		names[i] = subset.Name
+	}
+	return names
+}
+
+// processParameters formats the parameters one per line as "Name (Type) = Value"
+func processParameters(process *models.Process) string {
+	lines := make([]string, len(process.Parameters))
+	for i, parameter := range process.Parameters {
+		lines[i] = fmt.Sprintf("%s (%s) = %v", parameter.Name, parameter.Type, parameter.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// processVariables formats the variables one per line as "Name (Type)"
+func processVariables(process *models.Process) string {
+	lines := make([]string, len(process.Variables))
+	for i, variable := range process.Variables {
+		lines[i] = fmt.Sprintf("%s (%s)", variable.Name, variable.Type)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// processDataSource returns the data source as JSON, without the password
+func processDataSource(process *models.Process) string {
+	if process.DataSource == nil {
+		return ""
+	}
+	dataSource := *process.DataSource
+	dataSource.Password = ""
+	data, _ := json.Marshal(dataSource)
+	return string(data)
+}
+
+// choreTasks formats the tasks one per line as "Process(Name=Value, ...)"
+func choreTasks(chore *models.Chore) string {
+	lines := make([]string, len(chore.Tasks))
+	for i, task := range chore.Tasks {
+		parameters := make([]string, len(task.Parameters))
+		for j, parameter := range task.Parameters {
+			parameters[j] = fmt.Sprintf("%s=%v", parameter.Name, parameter.Value)
+		}
+		lines[i] = fmt.Sprintf("%s(%s)", task.ProcessName(), strings.Join(parameters, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
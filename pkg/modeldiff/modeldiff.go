@@ -0,0 +1,212 @@
+// Package modeldiff compares the models of two TM1 servers, for example to review what a
+// promotion from development to production would change.
+//
+//	result, err := modeldiff.Compare(ctx, dev, prod, modeldiff.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(result.Report())
+package modeldiff
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andreyea/tm1go/pkg/models"
+	"github.com/andreyea/tm1go/pkg/tm1"
+)
+
+// ObjectType identifies the kind of object a difference refers to
+type ObjectType string
+
+const (
+	ObjectCube      ObjectType = "Cube"
+	ObjectDimension ObjectType = "Dimension"
+	ObjectHierarchy ObjectType = "Hierarchy"
+	ObjectSubset    ObjectType = "Subset"
+	ObjectView      ObjectType = "View"
+	ObjectProcess   ObjectType = "Process"
+	ObjectChore     ObjectType = "Chore"
+)
+
+// allObjectTypes lists the object types in comparison order
+var allObjectTypes = []ObjectType{ObjectCube, ObjectDimension, ObjectHierarchy, ObjectSubset, ObjectView, ObjectProcess, ObjectChore}
+
+// ChangeKind tells whether an object exists on one server only or differs between them
+type ChangeKind string
+
+const (
+	OnlyInSource ChangeKind = "only in source"
+	OnlyInTarget ChangeKind = "only in target"
+	Modified     ChangeKind = "modified"
+)
+
+// PropertyDifference describes a property that differs between source and target. Single values
+// are held in Source and Target; for collections, the items present on one side only are listed.
+type PropertyDifference struct {
+	Property     string
+	Source       string
+	Target       string
+	OnlyInSource []string
+	OnlyInTarget []string
+}
+
+// Difference describes an object that differs between source and target
+type Difference struct {
+	Type       ObjectType
+	Name       string // Qualified name: "Dimension:Hierarchy", "Dimension:Hierarchy:Subset" or "Cube:View"
+	Kind       ChangeKind
+	Properties []PropertyDifference // Set when Kind is Modified
+}
+
+// Result holds the differences found by Compare
+type Result struct {
+	Differences []Difference
+}
+
+// Options controls what Compare looks at
+type Options struct {
+	Objects               []ObjectType // Object types to compare. Default: all
+	IncludeControlObjects bool         // Also compare control objects, whose names start with "}"
+}
+
+// IsEmpty reports whether the servers have no differences
+func (r *Result) IsEmpty() bool {
+	return len(r.Differences) == 0
+}
+
+// Compare compares the models of the source and target servers. Names are matched case- and
+// space-insensitively, like TM1 does. Hierarchies, subsets and views are only compared within
+// dimensions and cubes present on both servers; the Leaves hierarchies are skipped as TM1
+// derives them from the other hierarchies. Only public subsets and views are compared.
+func Compare(ctx context.Context, source, target *tm1.TM1Service, opts Options) (*Result, error) {
+	objects := opts.Objects
+	if len(objects) == 0 {
+		objects = allObjectTypes
+	}
+	selected := make(map[ObjectType]bool, len(objects))
+	for _, object := range objects {
+		selected[object] = true
+	}
+
+	c := &comparison{ctx: ctx, source: source, target: target, opts: opts, selected: selected, result: &Result{}}
+	steps := []struct {
+		enabled bool
+		name    string
+		compare func() error
+	}{
+		{c.wants(ObjectCube, ObjectView), "cubes", c.compareCubes},
+		{c.wants(ObjectDimension, ObjectHierarchy, ObjectSubset), "dimensions", c.compareDimensions},
+		{c.wants(ObjectProcess), "processes", c.compareProcesses},
+		{c.wants(ObjectChore), "chores", c.compareChores},
+	}
+	for _, step := range steps {
+		if !step.enabled {
+			continue
+		}
+		if err := step.compare(); err != nil {
+			return nil, fmt.Errorf("compare %s: %w", step.name, err)
+		}
+	}
+	return c.result, nil
+}
+
+// comparison carries the state of one Compare call
+type comparison struct {
+	ctx            context.Context
+	source, target *tm1.TM1Service
+	opts           Options
+	selected       map[ObjectType]bool
+	result         *Result
+}
+
+// wants reports whether any of the object types is compared.
+func (c *comparison) wants(objectTypes ...ObjectType) bool {
+	for _, objectType := range objectTypes {
+		if c.selected[objectType] {
+			return true
+		}
+	}
+	return false
+}
+
+// add records the objects present on one side only, if the object type is compared, and returns
+// the pairs of names present on both.
+func (c *comparison) add(objectType ObjectType, prefix string, sourceNames, targetNames []string) [][2]string {
+	onlyInSource, onlyInTarget, both := matchNames(sourceNames, targetNames)
+	if !c.wants(objectType) {
+		return both
+	}
+	for _, name := range onlyInSource {
+		c.result.Differences = append(c.result.Differences, Difference{Type: objectType, Name: prefix + name, Kind: OnlyInSource})
+	}
+	for _, name := range onlyInTarget {
+		c.result.Differences = append(c.result.Differences, Difference{Type: objectType, Name: prefix + name, Kind: OnlyInTarget})
+	}
+	return both
+}
+
+// modified records an object present on both sides when any of its properties differ.
+func (c *comparison) modified(objectType ObjectType, name string, props properties) {
+	if len(props) > 0 && c.wants(objectType) {
+		c.result.Differences = append(c.result.Differences, Difference{Type: objectType, Name: name, Kind: Modified, Properties: props})
+	}
+}
+
+// skip reports whether an object is a control object that is not compared.
+func (c *comparison) skip(name string) bool {
+	return !c.opts.IncludeControlObjects && strings.HasPrefix(name, "}")
+}
+
+// matchNames pairs the names of both sides, compared case- and space-insensitively, in sorted order.
+func matchNames(sourceNames, targetNames []string) (onlyInSource, onlyInTarget []string, both [][2]string) {
+	targets := make(map[string]string, len(targetNames))
+	for _, name := range targetNames {
+		targets[models.NormalizeName(name)] = name
+	}
+	sources := make(map[string]bool, len(sourceNames))
+	for _, name := range sourceNames {
+		key := models.NormalizeName(name)
+		sources[key] = true
+		if targetName, ok := targets[key]; ok {
+			both = append(both, [2]string{name, targetName})
+		} else {
+			onlyInSource = append(onlyInSource, name)
+		}
+	}
+	for _, name := range targetNames {
+		if !sources[models.NormalizeName(name)] {
+			onlyInTarget = append(onlyInTarget, name)
+		}
+	}
+
+	sort.Strings(onlyInSource)
+	sort.Strings(onlyInTarget)
+	sort.Slice(both, func(i, j int) bool { return both[i][0] < both[j][0] })
+	return onlyInSource, onlyInTarget, both
+}
+
+// properties collects the property differences of one object
+type properties []PropertyDifference
+
+// value records a property whose source and target values differ.
+func (p *properties) value(property, sourceValue, targetValue string) {
+	if sourceValue != targetValue {
+		*p = append(*p, PropertyDifference{Property: property, Source: sourceValue, Target: targetValue})
+	}
+}
+
+// set records a collection whose items, compared case- and space-insensitively, differ.
+func (p *properties) set(property string, sourceItems, targetItems []string) {
+	onlyInSource, onlyInTarget, _ := matchNames(sourceItems, targetItems)
+	if len(onlyInSource) > 0 || len(onlyInTarget) > 0 {
+		*p = append(*p, PropertyDifference{Property: property, OnlyInSource: onlyInSource, OnlyInTarget: onlyInTarget})
+	}
+}
+
+// normalizeCode makes rules and process code comparable across platforms
+func normalizeCode(code string) string {
+	return strings.TrimSpace(strings.ReplaceAll(code, "\r\n", "\n"))
+}
@@ -0,0 +1,141 @@
+package modeldiff
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreyea/tm1go/pkg/tm1"
+)
+
+// newTestServer serves fixed JSON responses by request path
+func newTestServer(t *testing.T, responses map[string]string) *tm1.TM1Service {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/Configuration/ProductVersion/$value" {
+			w.Write([]byte("11.8.02300.1"))
+			return
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := tm1.NewTM1Service(tm1.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewTM1Service() failed: %v", err)
+	}
+	return service
+}
+
+func TestCompare(t *testing.T) {
+	source := newTestServer(t, map[string]string{
+		"/ModelCubes()": `{"value":[
+			{"Name":"Sales","Rules":"['Total'] = N: 1;\r\nFEEDERS;","Dimensions":[{"Name":"Region"}]},
+			{"Name":"Plan","Dimensions":[{"Name":"Region"}]}]}`,
+		"/Cubes('Sales')/Views": `{"value":[
+			{"@odata.type":"#ibm.tm1.api.v1.MDXView","Name":"Default","MDX":"SELECT {[Region].[Total]} ON 0 FROM [Sales]"}]}`,
+		"/ModelDimensions()":                `{"value":[{"Name":"Region"}]}`,
+		"/Dimensions('Region')/Hierarchies": `{"value":[{"Name":"Region"},{"Name":"Leaves"}]}`,
+		"/Dimensions('Region')/Hierarchies('Region')": `{"Name":"Region",
+			"Elements":[{"Name":"Total","Type":"Consolidated"},{"Name":"North","Type":"Numeric"},{"Name":"South","Type":"Numeric"}],
+			"Edges":[{"ParentName":"Total","ComponentName":"North","Weight":1},{"ParentName":"Total","ComponentName":"South","Weight":1}],
+			"ElementAttributes":[{"Name":"Code","Type":"Alias"}],
+			"Subsets":[{"Name":"Top"}]}`,
+		"/Dimensions('Region')/Hierarchies('Region')/Subsets('Top')": `{"Name":"Top","Expression":"{[Region].[Total]}"}`,
+		"/Processes": `{"value":[{"Name":"Load","PrologProcedure":"nRows = 0;\r\nsFile = 'a.csv';",
+			"Parameters":[{"Name":"pYear","Type":"String","Value":"2024"}]}]}`,
+		"/Chores": `{"value":[{"Name":"Nightly","Active":true,"Tasks":[{"Process":{"Name":"Load"},"Parameters":[{"Name":"pYear","Value":"2024"}]}]},
+			{"Name":"}Control","Active":true}]}`,
+	})
+	target := newTestServer(t, map[string]string{
+		"/ModelCubes()": `{"value":[
+			{"Name":"sales","Rules":"['Total'] = N: 2;\nFEEDERS;","Dimensions":[{"Name":"Region"}]}]}`,
+		"/Cubes('sales')/Views": `{"value":[
+			{"@odata.type":"#ibm.tm1.api.v1.MDXView","Name":"Default","MDX":"SELECT {[Region].[North]} ON 0 FROM [Sales]"}]}`,
+		"/ModelDimensions()":                `{"value":[{"Name":"Region"},{"Name":"Product"}]}`,
+		"/Dimensions('Region')/Hierarchies": `{"value":[{"Name":"Region"}]}`,
+		"/Dimensions('Region')/Hierarchies('Region')": `{"Name":"Region",
+			"Elements":[{"Name":"Total","Type":"Consolidated"},{"Name":"North","Type":"Numeric"},{"Name":"South","Type":"String"},{"Name":"West","Type":"Numeric"}],
+			"Edges":[{"ParentName":"Total","ComponentName":"North","Weight":1},{"ParentName":"Total","ComponentName":"South","Weight":2}],
+			"ElementAttributes":[{"Name":"code","Type":"Alias"}],
+			"Subsets":[{"Name":"Top"}]}`,
+		"/Dimensions('Region')/Hierarchies('Region')/Subsets('Top')": `{"Name":"Top","Expression":"{[Region].[Total]}"}`,
+		"/Processes": `{"value":[{"Name":"Load","PrologProcedure":"nRows = 0;\nsFile = 'b.csv';",
+			"Parameters":[{"Name":"pYear","Type":"String","Value":"2024"}]}]}`,
+		"/Chores": `{"value":[{"Name":"Nightly","Active":false,"Tasks":[{"Process":{"Name":"Load"},"Parameters":[{"Name":"pYear","Value":"2024"}]}]}]}`,
+	})
+
+	result, err := Compare(context.Background(), source, target, Options{})
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+
+	want := []Difference{
+		{Type: ObjectCube, Name: "Plan", Kind: OnlyInSource},
+		{Type: ObjectCube, Name: "Sales", Kind: Modified, Properties: []PropertyDifference{
+			{Property: "Rules", Source: "['Total'] = N: 1;\nFEEDERS;", Target: "['Total'] = N: 2;\nFEEDERS;"},
+		}},
+		{Type: ObjectView, Name: "Sales:Default", Kind: Modified, Properties: []PropertyDifference{
+			{Property: "MDX", Source: "SELECT {[Region].[Total]} ON 0 FROM [Sales]", Target: "SELECT {[Region].[North]} ON 0 FROM [Sales]"},
+		}},
+		{Type: ObjectDimension, Name: "Product", Kind: OnlyInTarget},
+		{Type: ObjectHierarchy, Name: "Region:Region", Kind: Modified, Properties: []PropertyDifference{
+			{Property: "Elements", OnlyInTarget: []string{"West"}},
+			{Property: "Type of South", Source: "Numeric", Target: "String"},
+			{Property: "Weight of Total -> South", Source: "1", Target: "2"},
+		}},
+		{Type: ObjectProcess, Name: "Load", Kind: Modified, Properties: []PropertyDifference{
+			{Property: "Prolog", Source: "nRows = 0;\nsFile = 'a.csv';", Target: "nRows = 0;\nsFile = 'b.csv';"},
+		}},
+		{Type: ObjectChore, Name: "Nightly", Kind: Modified, Properties: []PropertyDifference{
+			{Property: "Active", Source: "true", Target: "false"},
+		}},
+	}
+	if !reflect.DeepEqual(result.Differences, want) {
+		t.Errorf("Differences =\n%+v\nwant\n%+v", result.Differences, want)
+	}
+
+	report := result.Report()
+	for _, line := range []string{
+		"Cube 'Plan': only in source\n",
+		"Dimension 'Product': only in target\n",
+		"  Elements:\n    + West\n",
+		"  Type of South: Numeric -> String\n",
+		"  Prolog:\n    - sFile = 'a.csv';\n    + sFile = 'b.csv';\n",
+	} {
+		if !strings.Contains(report, line) {
+			t.Errorf("report does not contain %q:\n%s", line, report)
+		}
+	}
+}
+
+func TestCompareSelectedObjects(t *testing.T) {
+	source := newTestServer(t, map[string]string{
+		"/Processes": `{"value":[{"Name":"Load"},{"Name":"Export"}]}`,
+	})
+	target := newTestServer(t, map[string]string{
+		"/Processes": `{"value":[{"Name":"load"}]}`,
+	})
+
+	result, err := Compare(context.Background(), source, target, Options{Objects: []ObjectType{ObjectProcess}})
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+	want := []Difference{{Type: ObjectProcess, Name: "Export", Kind: OnlyInSource}}
+	if !reflect.DeepEqual(result.Differences, want) {
+		t.Errorf("Differences = %+v, want %+v", result.Differences, want)
+	}
+
+	if report := (&Result{}).Report(); report != "No differences\n" {
+		t.Errorf("empty report = %q", report)
+	}
+}
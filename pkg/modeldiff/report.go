@@ -0,0 +1,98 @@
+package modeldiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report returns the differences as human-readable text, one object per paragraph:
+//
+//	Cube 'Sales': modified
+//	  Rules:
+//	    - ['Total'] = N: 1;
+//	    + ['Total'] = N: 2;
+//	Process 'Load Sales': only in source
+//
+// Multi-line values such as code are reduced to the lines found on one side only, prefixed with
+// "-" for the source and "+" for the target.
+func (r *Result) Report() string {
+	if r.IsEmpty() {
+		return "No differences\n"
+	}
+
+	var sb strings.Builder
+	for _, difference := range r.Differences {
+		fmt.Fprintf(&sb, "%s '%s': %s\n", difference.Type, difference.Name, difference.Kind)
+		for _, property := range difference.Properties {
+			writeProperty(&sb, property)
+		}
+	}
+	return sb.String()
+}
+
+// writeProperty writes one property difference, indented below its object.
+func writeProperty(sb *strings.Builder, property PropertyDifference) {
+	if property.OnlyInSource != nil || property.OnlyInTarget != nil {
+		fmt.Fprintf(sb, "  %s:\n", property.Property)
+		for _, item := range property.OnlyInSource {
+			fmt.Fprintf(sb, "    - %s\n", item)
+		}
+		for _, item := range property.OnlyInTarget {
+			fmt.Fprintf(sb, "    + %s\n", item)
+		}
+		return
+	}
+
+	if !strings.Contains(property.Source, "\n") && !strings.Contains(property.Target, "\n") {
+		fmt.Fprintf(sb, "  %s: %s -> %s\n", property.Property, quote(property.Source), quote(property.Target))
+		return
+	}
+
+	fmt.Fprintf(sb, "  %s:\n", property.Property)
+	onlyInSource, onlyInTarget := lineDifference(property.Source, property.Target)
+	for _, line := range onlyInSource {
+		fmt.Fprintf(sb, "    - %s\n", line)
+	}
+	for _, line := range onlyInTarget {
+		fmt.Fprintf(sb, "    + %s\n", line)
+	}
+	if len(onlyInSource) == 0 && len(onlyInTarget) == 0 {
+		sb.WriteString("    same lines in a different order\n")
+	}
+}
+
+// lineDifference returns the lines of each text missing from the other, counting repeated lines.
+func lineDifference(source, target string) (onlyInSource, onlyInTarget []string) {
+	counts := make(map[string]int)
+	for _, line := range strings.Split(target, "\n") {
+		counts[line]++
+	}
+	for _, line := range strings.Split(source, "\n") {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			onlyInSource = append(onlyInSource, line)
+		}
+	}
+
+	counts = make(map[string]int)
+	for _, line := range strings.Split(source, "\n") {
+		counts[line]++
+	}
+	for _, line := range strings.Split(target, "\n") {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			onlyInTarget = append(onlyInTarget, line)
+		}
+	}
+	return onlyInSource, onlyInTarget
+}
+
+// quote marks empty values so they are not mistaken for missing output
+func quote(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	return value
+}
@@ -160,6 +160,8 @@ type Subset struct {
 	Alias         string   `json:"Alias,omitempty"`
 	Expression    string   `json:"Expression,omitempty"`
 	Elements      []string `json:"-"` // For static subsets, handled separately
+	Private       bool     `json:"-"` // Subset is a private subset of the current user
+	Bind          bool     `json:"-"` // Views refer to the stored subset of this name instead of embedding its definition
 }
 
 // NewSubset creates a new Subset instance
@@ -321,6 +323,12 @@ func SubsetFromDict(dict map[string]interface{}) (*Subset, error) {
 		subset.Name = name
 	}
 
+	// A subset read with its id is stored on the server, public or private
+	if id, ok := dict["@odata.id"].(string); ok && subset.Name != "" {
+		subset.Bind = true
+		subset.Private = strings.Contains(id, "PrivateSubsets(")
+	}
+
 	// Extract alias
 	if alias, ok := dict["Alias"].(string); ok {
 		subset.Alias = alias
@@ -1,5 +1,36 @@
 package models
 
+import "encoding/json"
+
 // Sandbox represents a TM1 sandbox.
 type Sandbox struct {
+	Name                      string `json:"Name"`
+	IncludeInSandboxDimension bool   `json:"IncludeInSandboxDimension"`
+	IsLoaded                  bool   `json:"IsLoaded,omitempty"`
+	IsActive                  bool   `json:"IsActive,omitempty"`
+	IsQueued                  bool   `json:"IsQueued,omitempty"`
+}
+
+// NewSandbox creates a new Sandbox instance that is included in the sandbox dimension.
+func NewSandbox(name string) *Sandbox {
+	return &Sandbox{
+		Name:                      name,
+		IncludeInSandboxDimension: true,
+	}
+}
+
+// Body returns the JSON representation for sandbox create/update requests.
+// Read-only state properties (IsLoaded, IsActive, IsQueued) are not sent.
+func (s *Sandbox) Body() (string, error) {
+	payload := map[string]interface{}{
+		"Name":                      s.Name,
+		"IncludeInSandboxDimension": s.IncludeInSandboxDimension,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonData), nil
 }
@@ -0,0 +1,227 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidationProblem describes a structural problem found by Validate
+type ValidationProblem struct {
+	Hierarchy string // Hierarchy name
+	Object    string // Element, edge or attribute concerned, if any
+	Message   string
+}
+
+// String returns the problem as a single line
+func (p ValidationProblem) String() string {
+	if p.Object == "" {
+		return fmt.Sprintf("hierarchy '%s': %s", p.Hierarchy, p.Message)
+	}
+	return fmt.Sprintf("hierarchy '%s': %s: %s", p.Hierarchy, p.Object, p.Message)
+}
+
+// ValidationError lists all problems found by Validate
+type ValidationError struct {
+	Problems []ValidationProblem
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		lines[i] = problem.String()
+	}
+	if len(lines) == 1 {
+		return "invalid model: " + lines[0]
+	}
+	return fmt.Sprintf("invalid model, %d problems: %s", len(lines), strings.Join(lines, "; "))
+}
+
+// Validate checks the dimension and all of its hierarchies without contacting a server.
+// It returns a *ValidationError listing every problem, or nil.
+func (d *Dimension) Validate() error {
+	var problems []ValidationProblem
+	if d.Name == "" {
+		problems = append(problems, ValidationProblem{Message: "dimension name is empty"})
+	}
+
+	seen := make(map[string]string)
+	for i := range d.Hierarchies {
+		hierarchy := &d.Hierarchies[i]
+		key := NormalizeName(hierarchy.Name)
+		if first, ok := seen[key]; ok {
+			problems = append(problems, ValidationProblem{Hierarchy: hierarchy.Name, Message: fmt.Sprintf("duplicate of hierarchy '%s'", first)})
+			continue
+		}
+		seen[key] = hierarchy.Name
+		problems = append(problems, hierarchy.problems()...)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Validate checks the hierarchy for problems TM1 would reject or silently change: duplicate element
+// or attribute names (compared case- and space-insensitively), invalid types, edges to missing elements,
+// duplicate edges, cycles, elements with children that are not consolidated, and alias attributes
+// named like an element.
+// It returns a *ValidationError listing every problem, or nil.
+func (h *Hierarchy) Validate() error {
+	if problems := h.problems(); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (h *Hierarchy) problems() []ValidationProblem {
+	var problems []ValidationProblem
+	report := func(object, format string, args ...interface{}) {
+		problems = append(problems, ValidationProblem{Hierarchy: h.Name, Object: object, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if h.Name == "" {
+		report("", "hierarchy name is empty")
+	}
+
+	elements := make(map[string]Element, len(h.Elements))
+	for _, element := range h.Elements {
+		object := fmt.Sprintf("element '%s'", element.Name)
+		if element.Name == "" {
+			report("", "element name is empty")
+			continue
+		}
+		key := NormalizeName(element.Name)
+		if first, ok := elements[key]; ok {
+			report(object, "duplicate of element '%s'", first.Name)
+			continue
+		}
+		elements[key] = element
+		switch element.Type {
+		case ElementTypeNumeric, ElementTypeString, ElementTypeConsolidated:
+		default:
+			report(object, "invalid type '%s'", element.Type)
+		}
+	}
+
+	children := make(map[string][]string)
+	edges := make(map[[2]string]bool, len(h.Edges))
+	for _, edge := range h.Edges {
+		object := fmt.Sprintf("edge '%s' -> '%s'", edge.ParentName, edge.ComponentName)
+		parentKey, componentKey := NormalizeName(edge.ParentName), NormalizeName(edge.ComponentName)
+		parent, parentOK := elements[parentKey]
+		_, componentOK := elements[componentKey]
+		if !parentOK {
+			report(object, "parent element '%s' does not exist", edge.ParentName)
+		}
+		if !componentOK {
+			report(object, "component element '%s' does not exist", edge.ComponentName)
+		}
+		if !parentOK || !componentOK {
+			continue
+		}
+		if parentKey == componentKey {
+			report(object, "element is its own parent")
+			continue
+		}
+		key := [2]string{parentKey, componentKey}
+		if edges[key] {
+			report(object, "duplicate edge")
+			continue
+		}
+		edges[key] = true
+		if len(children[parentKey]) == 0 && parent.Type != ElementTypeConsolidated {
+			report(fmt.Sprintf("element '%s'", parent.Name), "has children but type '%s'", parent.Type)
+		}
+		children[parentKey] = append(children[parentKey], componentKey)
+	}
+
+	for _, cycle := range findCycles(h.Elements, elements, children) {
+		report("", "cycle %s", strings.Join(cycle, " -> "))
+	}
+
+	attributes := make(map[string]string, len(h.ElementAttributes))
+	for _, attribute := range h.ElementAttributes {
+		object := fmt.Sprintf("attribute '%s'", attribute.Name)
+		if attribute.Name == "" {
+			report("", "attribute name is empty")
+			continue
+		}
+		key := NormalizeName(attribute.Name)
+		if first, ok := attributes[key]; ok {
+			report(object, "duplicate of attribute '%s'", first)
+			continue
+		}
+		attributes[key] = attribute.Name
+		switch attribute.AttributeType {
+		case AttributeTypeNumeric, AttributeTypeString:
+		case AttributeTypeAlias:
+			if element, ok := elements[key]; ok {
+				report(object, "alias name collides with element '%s'", element.Name)
+			}
+		default:
+			report(object, "invalid type '%s'", attribute.AttributeType)
+		}
+	}
+
+	return problems
+}
+
+// findCycles returns each cycle of the edge graph once, as element names starting and ending
+// with the same element.
+func findCycles(order []Element, elements map[string]Element, children map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make(map[string]int, len(elements))
+	var stack []string
+	var cycles [][]string
+	reported := make(map[string]bool)
+
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = active
+		stack = append(stack, key)
+		for _, child := range children[key] {
+			switch state[child] {
+			case unvisited:
+				visit(child)
+			case active:
+				start := len(stack) - 1
+				for stack[start] != child {
+					start--
+				}
+				members := append([]string{}, stack[start:]...)
+				sorted := append([]string{}, members...)
+				sort.Strings(sorted)
+				if id := strings.Join(sorted, "\x00"); !reported[id] {
+					reported[id] = true
+					cycle := make([]string, 0, len(members)+1)
+					for _, member := range members {
+						cycle = append(cycle, elements[member].Name)
+					}
+					cycles = append(cycles, append(cycle, elements[child].Name))
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[key] = done
+	}
+
+	for _, element := range order {
+		if key := NormalizeName(element.Name); state[key] == unvisited {
+			visit(key)
+		}
+	}
+	return cycles
+}
+
+// NormalizeName returns the form TM1 compares object names in: without spaces and in
+// lower case. Names that normalize to the same string refer to the same object.
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // ViewDefinition represents a TM1 view (NativeView or MDXView)
@@ -136,7 +137,8 @@ func ViewSelectedElementFromDict(dict map[string]interface{}) (*ViewSelectedElem
 }
 
 // NativeView represents a TM1 NativeView
-// Columns/Rows/Titles subsets use models.Subset
+// Columns/Rows/Titles subsets use models.Subset; their definition is stored with the
+// view unless Subset.Bind refers the view to the stored subset of that name.
 // Selected uses ViewSelectedElement for title selection.
 type NativeView struct {
 	Type                 string          `json:"@odata.type,omitempty"`
@@ -161,8 +163,22 @@ func (v *NativeView) GetName() string {
 // Body returns the JSON representation for a NativeView create/update request
 func (v *NativeView) Body(static bool) (string, error) {
 	type axisBody struct {
-		Subset   subsetBody `json:"Subset"`
-		Selected string     `json:"Selected@odata.bind,omitempty"`
+		Subset     *subsetBody `json:"Subset,omitempty"`
+		SubsetBind string      `json:"Subset@odata.bind,omitempty"`
+		Selected   string      `json:"Selected@odata.bind,omitempty"`
+	}
+
+	// Subsets marked with Bind refer to the stored subset, all others are embedded in the view.
+	buildAxis := func(subset *Subset) (axisBody, error) {
+		if subset.Bind && subset.Name != "" {
+			binding, err := buildSubsetBinding(subset)
+			return axisBody{SubsetBind: binding}, err
+		}
+		body, err := buildSubsetBody(subset, static)
+		if err != nil {
+			return axisBody{}, err
+		}
+		return axisBody{Subset: &body}, nil
 	}
 
 	type nativeViewBody struct {
@@ -194,11 +210,11 @@ func (v *NativeView) Body(static bool) (string, error) {
 		if column.Subset == nil {
 			return "", fmt.Errorf("column subset is required")
 		}
-		subsetBody, err := buildSubsetBody(column.Subset, static)
+		axis, err := buildAxis(column.Subset)
 		if err != nil {
 			return "", err
 		}
-		body.Columns = append(body.Columns, axisBody{Subset: subsetBody})
+		body.Columns = append(body.Columns, axis)
 	}
 
 	body.Rows = make([]axisBody, 0, len(v.Rows))
@@ -206,11 +222,11 @@ func (v *NativeView) Body(static bool) (string, error) {
 		if row.Subset == nil {
 			return "", fmt.Errorf("row subset is required")
 		}
-		subsetBody, err := buildSubsetBody(row.Subset, static)
+		axis, err := buildAxis(row.Subset)
 		if err != nil {
 			return "", err
 		}
-		body.Rows = append(body.Rows, axisBody{Subset: subsetBody})
+		body.Rows = append(body.Rows, axis)
 	}
 
 	if len(v.Titles) > 0 {
@@ -219,11 +235,10 @@ func (v *NativeView) Body(static bool) (string, error) {
 			if title.Subset == nil {
 				return "", fmt.Errorf("title subset is required")
 			}
-			subsetBody, err := buildSubsetBody(title.Subset, static)
+			axis, err := buildAxis(title.Subset)
 			if err != nil {
 				return "", err
 			}
-			axis := axisBody{Subset: subsetBody}
 			if title.Selected != nil && title.Selected.Name != "" {
 				binding, err := buildSelectedBinding(title.Selected)
 				if err != nil {
@@ -243,6 +258,151 @@ func (v *NativeView) Body(static bool) (string, error) {
 	return string(jsonData), nil
 }
 
+// ToMDX returns the MDX query equivalent to the native view. Static subsets become
+// element sets, dynamic subsets use their expression and named subsets without a
+// definition are referenced with TM1SubsetToSet. Multiple subsets on an axis are
+// cross joined, suppression adds NON EMPTY and the selected title elements form the
+// WHERE clause. The view's Cube must be set.
+func (v *NativeView) ToMDX() (string, error) {
+	if v.Cube == nil || v.Cube.Name == "" {
+		return "", fmt.Errorf("view %s has no cube", v.Name)
+	}
+	if len(v.Columns) == 0 {
+		return "", fmt.Errorf("view %s has no columns", v.Name)
+	}
+
+	columns, err := axisSetMDX(v.Columns)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	builder.WriteString("SELECT ")
+	if v.SuppressEmptyColumns {
+		builder.WriteString("NON EMPTY ")
+	}
+	builder.WriteString(columns + " ON COLUMNS")
+
+	if len(v.Rows) > 0 {
+		rows, err := axisSetMDX(v.Rows)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(", ")
+		if v.SuppressEmptyRows {
+			builder.WriteString("NON EMPTY ")
+		}
+		builder.WriteString(rows + " ON ROWS")
+	}
+
+	builder.WriteString(" FROM " + QuoteMDXName(v.Cube.Name))
+
+	members := make([]string, 0, len(v.Titles))
+	for _, title := range v.Titles {
+		member, err := titleMemberMDX(title)
+		if err != nil {
+			return "", err
+		}
+		if member != "" {
+			members = append(members, member)
+		}
+	}
+	if len(members) > 0 {
+		builder.WriteString(" WHERE (" + strings.Join(members, ", ") + ")")
+	}
+
+	return builder.String(), nil
+}
+
+// axisSetMDX returns the set of an axis, cross joining the sets of its subsets.
+func axisSetMDX(axes []ViewAxis) (string, error) {
+	sets := make([]string, 0, len(axes))
+	for _, axis := range axes {
+		if axis.Subset == nil {
+			return "", fmt.Errorf("axis subset is required")
+		}
+		set, err := subsetMDX(axis.Subset)
+		if err != nil {
+			return "", err
+		}
+		sets = append(sets, set)
+	}
+
+	if len(sets) == 1 {
+		return sets[0], nil
+	}
+	return "{" + strings.Join(sets, " * ") + "}", nil
+}
+
+// subsetMDX returns the MDX set of a subset.
+func subsetMDX(subset *Subset) (string, error) {
+	if subset.DimensionName == "" || subset.HierarchyName == "" {
+		return "", fmt.Errorf("subset dimension and hierarchy are required")
+	}
+
+	switch {
+	case subset.Expression != "":
+		return subset.Expression, nil
+	case len(subset.Elements) > 0:
+		members := make([]string, len(subset.Elements))
+		for i, element := range subset.Elements {
+			members[i] = memberMDX(subset.DimensionName, subset.HierarchyName, element)
+		}
+		return "{" + strings.Join(members, ", ") + "}", nil
+	case subset.Name != "":
+		return TM1SubsetToSetMDX(subset.DimensionName, subset.HierarchyName, subset.Name), nil
+	default:
+		return "", fmt.Errorf("subset of %s has neither elements nor an expression", subset.DimensionName)
+	}
+}
+
+// titleMemberMDX returns the selected member of a title axis. Without a selection the
+// first element of a static subset is used; otherwise the title is left to the
+// hierarchy's default member and an empty string is returned.
+func titleMemberMDX(title ViewTitleAxis) (string, error) {
+	if title.Selected != nil && title.Selected.Name != "" {
+		dimension, hierarchy := title.Selected.DimensionName, title.Selected.HierarchyName
+		if dimension == "" && title.Subset != nil {
+			dimension, hierarchy = title.Subset.DimensionName, title.Subset.HierarchyName
+		}
+		if hierarchy == "" {
+			hierarchy = dimension
+		}
+		if dimension == "" {
+			return "", fmt.Errorf("selected title element %s has no dimension", title.Selected.Name)
+		}
+		return memberMDX(dimension, hierarchy, title.Selected.Name), nil
+	}
+
+	if title.Subset != nil && len(title.Subset.Elements) > 0 {
+		return memberMDX(title.Subset.DimensionName, title.Subset.HierarchyName, title.Subset.Elements[0]), nil
+	}
+	return "", nil
+}
+
+func memberMDX(dimension, hierarchy, element string) string {
+	return QuoteMDXName(dimension) + "." + QuoteMDXName(hierarchy) + "." + QuoteMDXName(element)
+}
+
+// QuoteMDXName wraps a TM1 object name in MDX brackets, escaping closing brackets.
+func QuoteMDXName(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+// QuoteMDXString returns an MDX string literal, doubling embedded quotes.
+func QuoteMDXString(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+// TM1SubsetToSetMDX returns a TM1SUBSETTOSET set expression for a named subset.
+// An empty hierarchy defaults to the dimension's same-named hierarchy.
+func TM1SubsetToSetMDX(dimension, hierarchy, subsetName string) string {
+	if hierarchy == "" {
+		hierarchy = dimension
+	}
+	return fmt.Sprintf("{TM1SUBSETTOSET(%s.%s,%s)}", QuoteMDXName(dimension), QuoteMDXName(hierarchy), QuoteMDXString(subsetName))
+}
+
 // MDXView represents a TM1 MDX View
 // Meta is optional and can contain Aliases, ContextSets, and ExpandAboves.
 type MDXView struct {
@@ -313,6 +473,23 @@ func buildSubsetBody(subset *Subset, static bool) (subsetBody, error) {
 	return body, nil
 }
 
+func buildSubsetBinding(subset *Subset) (string, error) {
+	if subset.DimensionName == "" || subset.HierarchyName == "" {
+		return "", fmt.Errorf("subset dimension and hierarchy are required")
+	}
+
+	subsetsType := "Subsets"
+	if subset.Private {
+		subsetsType = "PrivateSubsets"
+	}
+
+	return fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/%s('%s')",
+		url.PathEscape(subset.DimensionName),
+		url.PathEscape(subset.HierarchyName),
+		subsetsType,
+		url.PathEscape(subset.Name)), nil
+}
+
 func buildSelectedBinding(selected *ViewSelectedElement) (string, error) {
 	if selected.DimensionName == "" || selected.HierarchyName == "" || selected.Name == "" {
 		return "", fmt.Errorf("selected element must include dimension, hierarchy, and name")
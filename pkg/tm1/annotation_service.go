@@ -0,0 +1,165 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// AnnotationService handles cell annotations (comments).
+type AnnotationService struct {
+	rest *RestService
+}
+
+// NewAnnotationService creates a new AnnotationService instance.
+func NewAnnotationService(rest *RestService) *AnnotationService {
+	return &AnnotationService{rest: rest}
+}
+
+const annotationExpand = "DimensionalContext($select=Name)"
+
+// CellAnnotations lists the annotations of one cell of a cellset.
+type CellAnnotations struct {
+	Ordinal     int      // Cell ordinal within the cellset
+	Elements    []string // Element names, one per hierarchy in axis order
+	Annotations []Annotation
+}
+
+// GetAll retrieves all annotations of a cube.
+func (as *AnnotationService) GetAll(ctx context.Context, cubeName string) ([]Annotation, error) {
+	endpoint := fmt.Sprintf("/Cubes('%s')/Annotations?$expand=%s", escapeODataKey(cubeName), annotationExpand)
+
+	var response struct {
+		Value []Annotation `json:"value"`
+	}
+	if err := as.rest.JSON(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("get annotations: %w", err)
+	}
+
+	for i := range response.Value {
+		if response.Value[i].ObjectName == "" {
+			response.Value[i].ObjectName = cubeName
+		}
+	}
+	return response.Value, nil
+}
+
+// Get retrieves an annotation by ID.
+func (as *AnnotationService) Get(ctx context.Context, annotationID string) (*Annotation, error) {
+	endpoint := fmt.Sprintf("/Annotations('%s')?$expand=%s", escapeODataKey(annotationID), annotationExpand)
+
+	var annotation Annotation
+	if err := as.rest.JSON(ctx, http.MethodGet, endpoint, nil, &annotation); err != nil {
+		return nil, fmt.Errorf("get annotation: %w", err)
+	}
+	return &annotation, nil
+}
+
+// Create adds an annotation to the cell at coordinates, given as one element per cube dimension in
+// cube order. Elements may be qualified as "dimension:hierarchy:element" (see WriteValuesByCoords).
+// It returns the annotation as created by the server, including its ID.
+func (as *AnnotationService) Create(ctx context.Context, cubeName string, coordinates []string, text string) (*Annotation, error) {
+	dimensions, err := NewCellService(as.rest).getDimensionNamesForCube(ctx, cubeName)
+	if err != nil {
+		return nil, err
+	}
+	if len(coordinates) != len(dimensions) {
+		return nil, fmt.Errorf("annotation has %d elements but cube '%s' has %d dimensions", len(coordinates), cubeName, len(dimensions))
+	}
+
+	members := make([]string, len(coordinates))
+	elements := make([]string, len(coordinates))
+	for i, coordinate := range coordinates {
+		dim, hier, elem := resolveCoordinate(dimensions[i], coordinate)
+		members[i] = fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/Members('%s')",
+			escapeODataKey(dim), escapeODataKey(hier), escapeODataKey(elem))
+		elements[i] = elem
+	}
+
+	payload := map[string]interface{}{
+		"Text": text,
+		"ApplicationContext": []map[string]interface{}{{
+			"Facet@odata.bind": "ApplicationContextFacets('}Cubes')",
+			"Value":            cubeName,
+		}},
+		"DimensionalContext@odata.bind": members,
+		"objectName":                    cubeName,
+		"commentValue":                  text,
+		"commentType":                   "ANNOTATION",
+		"commentLocation":               strings.Join(elements, ","),
+	}
+
+	var annotation Annotation
+	if err := as.rest.JSON(ctx, http.MethodPost, "/Annotations", payload, &annotation); err != nil {
+		return nil, fmt.Errorf("create annotation: %w", err)
+	}
+	return &annotation, nil
+}
+
+// Update replaces the text of an existing annotation.
+func (as *AnnotationService) Update(ctx context.Context, annotation *Annotation) error {
+	if annotation.ID == "" {
+		return fmt.Errorf("annotation ID is required")
+	}
+
+	payload := map[string]interface{}{
+		"Text":         annotation.Text,
+		"commentValue": annotation.Text,
+		"commentType":  "ANNOTATION",
+	}
+	endpoint := fmt.Sprintf("/Annotations('%s')", escapeODataKey(annotation.ID))
+	if err := as.rest.JSON(ctx, http.MethodPatch, endpoint, payload, nil); err != nil {
+		return fmt.Errorf("update annotation: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes an annotation by ID.
+func (as *AnnotationService) Delete(ctx context.Context, annotationID string) error {
+	resp, err := as.rest.Delete(ctx, fmt.Sprintf("/Annotations('%s')", escapeODataKey(annotationID)))
+	if err != nil {
+		return fmt.Errorf("delete annotation: %w", err)
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// GetForCells executes an MDX query and returns the annotations of its annotated cells, ordered by
+// cell ordinal. Cells without annotations are omitted.
+func (as *AnnotationService) GetForCells(ctx context.Context, mdx string, sandboxName string) ([]CellAnnotations, error) {
+	cells := NewCellService(as.rest)
+	cellsetID, err := cells.CreateCellset(ctx, mdx, sandboxName)
+	if err != nil {
+		return nil, fmt.Errorf("create cellset: %w", err)
+	}
+	defer cells.DeleteCellset(ctx, cellsetID, sandboxName)
+
+	endpoint := fmt.Sprintf("/Cellsets('%s')/Cells?$select=Ordinal,Annotated&$expand=Members($select=Name),Annotations($expand=%s)",
+		cellsetID, annotationExpand)
+	if sandboxName != "" {
+		endpoint = addSandboxParam(endpoint, sandboxName)
+	}
+
+	var response struct {
+		Value []Cell `json:"value"`
+	}
+	if err := as.rest.JSON(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("get cell annotations: %w", err)
+	}
+
+	result := make([]CellAnnotations, 0, len(response.Value))
+	for _, cell := range response.Value {
+		if len(cell.Annotations) == 0 {
+			continue
+		}
+		elements := make([]string, len(cell.Members))
+		for i, member := range cell.Members {
+			elements[i] = member.Name
+		}
+		result = append(result, CellAnnotations{Ordinal: cell.Ordinal, Elements: elements, Annotations: cell.Annotations})
+	}
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func newTestAnnotationService(t *testing.T, handler http.HandlerFunc) *AnnotationService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	rest, _ := NewRestService(Config{Address: "localhost", Port: 8882, SSL: false})
+	rest.SetBaseURL(server.URL)
+	return NewAnnotationService(rest)
+}
+
+func TestAnnotationService_Create(t *testing.T) {
+	var payload map[string]interface{}
+	service := newTestAnnotationService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/Cubes('Sales')/Dimensions":
+			w.Write([]byte(`{"value":[{"Name":"Version"},{"Name":"Region"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/Annotations":
+			json.NewDecoder(r.Body).Decode(&payload)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"42","Text":"Check this"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	annotation, err := service.Create(context.Background(), "Sales", []string{"Actual", "Region:By Manager:O'Neil"}, "Check this")
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if annotation.ID != "42" {
+		t.Errorf("ID = %q", annotation.ID)
+	}
+
+	wantMembers := []interface{}{
+		"Dimensions('Version')/Hierarchies('Version')/Members('Actual')",
+		"Dimensions('Region')/Hierarchies('By%20Manager')/Members('O%27%27Neil')",
+	}
+	if !reflect.DeepEqual(payload["DimensionalContext@odata.bind"], wantMembers) {
+		t.Errorf("DimensionalContext = %v", payload["DimensionalContext@odata.bind"])
+	}
+	if payload["objectName"] != "Sales" || payload["commentLocation"] != "Actual,O'Neil" || payload["Text"] != "Check this" {
+		t.Errorf("payload = %v", payload)
+	}
+
+	if _, err := service.Create(context.Background(), "Sales", []string{"Actual"}, "x"); err == nil {
+		t.Error("Create() with missing coordinates succeeded")
+	}
+}
+
+func TestAnnotationService_GetAllUpdateDelete(t *testing.T) {
+	var requests []string
+	var patch map[string]interface{}
+	service := newTestAnnotationService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"value":[{"ID":"1","Text":"a","DimensionalContext":[{"Name":"Actual"},{"Name":"North"}]}]}`))
+		case http.MethodPatch:
+			json.NewDecoder(r.Body).Decode(&patch)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	annotations, err := service.GetAll(context.Background(), "Sales")
+	if err != nil {
+		t.Fatalf("GetAll() failed: %v", err)
+	}
+	if len(annotations) != 1 || annotations[0].ObjectName != "Sales" || !reflect.DeepEqual(annotations[0].Elements(), []string{"Actual", "North"}) {
+		t.Errorf("annotations = %+v", annotations)
+	}
+
+	annotations[0].Text = "b"
+	if err := service.Update(context.Background(), &annotations[0]); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	if patch["Text"] != "b" || patch["commentValue"] != "b" {
+		t.Errorf("patch = %v", patch)
+	}
+	if err := service.Delete(context.Background(), "1"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+
+	want := []string{"GET /Cubes('Sales')/Annotations", "PATCH /Annotations('1')", "DELETE /Annotations('1')"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v", requests)
+	}
+}
+
+func TestAnnotationService_GetForCells(t *testing.T) {
+	deleted := false
+	service := newTestAnnotationService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"abc"}`))
+		case r.URL.Path == "/Cellsets('abc')/Cells":
+			w.Write([]byte(`{"value":[
+				{"Ordinal":0,"Annotated":false,"Members":[{"Name":"Actual"},{"Name":"North"}],"Annotations":[]},
+				{"Ordinal":1,"Annotated":true,"Members":[{"Name":"Actual"},{"Name":"South"}],"Annotations":[{"ID":"7","Text":"late"}]}]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/Cellsets('abc')":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	cells, err := service.GetForCells(context.Background(), "SELECT ...", "")
+	if err != nil {
+		t.Fatalf("GetForCells() failed: %v", err)
+	}
+	if len(cells) != 1 || cells[0].Ordinal != 1 || cells[0].Annotations[0].ID != "7" || !reflect.DeepEqual(cells[0].Elements, []string{"Actual", "South"}) {
+		t.Errorf("cells = %+v", cells)
+	}
+	if !deleted {
+		t.Error("cellset was not deleted")
+	}
+}
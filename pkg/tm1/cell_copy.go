@@ -0,0 +1,381 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CopySource selects the cells read by CopyData: the result of MDX, or of a cube view when MDX is empty.
+type CopySource struct {
+	MDX         string
+	CubeName    string
+	ViewName    string
+	Private     bool
+	SandboxName string
+}
+
+// CopyMapping turns the elements of one source dimension into elements of a target dimension.
+// Without a mapping, a target dimension takes the elements of the source dimension of the same name.
+type CopyMapping struct {
+	Dimension       string                              // Source dimension, or "dimension:hierarchy"
+	TargetDimension string                              // Target dimension, or "dimension:hierarchy". Default: Dimension
+	Element         string                              // Fixed target element for every cell; the source dimension is not read
+	Lookup          map[string]string                   // Source element -> target element, matched case- and space-insensitively
+	SkipUnmapped    bool                                // Skip cells whose element is not in Lookup instead of keeping the element
+	Func            func(element string) (string, bool) // Maps an element after Lookup; false skips the cell
+}
+
+// CopyDataParams controls CopyData.
+type CopyDataParams struct {
+	Mappings         []CopyMapping
+	Target           *TM1Service // Server to write to. Default: the server of the CellService
+	SandboxName      string      // Target sandbox
+	Increment        bool        // Add copied values to the current target values instead of replacing them
+	SkipZeros        bool        // Do not copy cells whose value is null, zero or an empty string
+	SkipConsolidated bool        // Do not copy consolidated cells
+	SkipRuleDerived  bool        // Do not copy rule-derived cells
+	PageSize         int         // Source cells read per request (default: DefaultCellsetPageSize)
+	Write            WriteParams // Chunking of the target writes
+}
+
+// CopyDataReport summarises a CopyData call.
+type CopyDataReport struct {
+	CellsRead    int // Source cells read
+	CellsSkipped int // Source cells skipped by SkipZeros, SkipConsolidated, SkipRuleDerived or a mapping
+	*WriteReport     // Outcome of the target writes
+}
+
+// copyColumn describes where the element of one target dimension comes from.
+type copyColumn struct {
+	source  int // Source hierarchy index, or -1 for a fixed element
+	mapping *CopyMapping
+	lookup  map[string]string
+}
+
+// CopyData copies cells from a source MDX query or view into targetCube, optionally on another server.
+// Source dimensions that are not mapped to a target dimension are dropped: cells that end up on the
+// same target cell are added up (string cells keep the last value). Every target dimension must
+// be on the source axes or have a mapping with a fixed Element.
+//
+// The source is read page by page and written in chunks. When every source dimension is copied to a
+// target dimension without a Lookup or Func, no two source cells can meet on a target cell, and chunks
+// are written as soon as they fill, so memory stays bounded by Write.ChunkSize times Write.Concurrency.
+// Otherwise the copied cells are held in memory until the source is read completely, so memory grows
+// with the number of target cells. With Increment, the current target values are read before each
+// chunk is written; this is not atomic. A chunk that fails to write stops the copy once the other
+// chunks of its batch are written. Errors after writing started are returned together with the
+// report, whose Failed chunks hold the cells that were not written.
+//
+//	report, err := tm1.Cells.CopyData(ctx, tm1.CopySource{MDX: mdx}, "Sales", tm1.CopyDataParams{
+//		Mappings: []tm1.CopyMapping{{Dimension: "Version", Lookup: map[string]string{"Actual": "Forecast"}}},
+//	})
+func (cs *CellService) CopyData(ctx context.Context, source CopySource, targetCube string, params CopyDataParams) (*CopyDataReport, error) {
+	target := cs
+	if params.Target != nil {
+		target = params.Target.Cells
+	}
+
+	targetDimensions, err := target.getDimensionNamesForCube(ctx, targetCube)
+	if err != nil {
+		return nil, err
+	}
+
+	streamParams := StreamParams{PageSize: params.PageSize, SandboxName: source.SandboxName}
+	if params.SkipConsolidated || params.SkipRuleDerived {
+		streamParams.CellProperties = []string{"Ordinal", "Value", "Consolidated", "RuleDerived"}
+	}
+	var stream *CellsetStream
+	if source.MDX != "" {
+		stream, err = cs.ExecuteMDXStream(ctx, source.MDX, streamParams)
+	} else {
+		stream, err = cs.ExecuteViewStream(ctx, source.CubeName, source.ViewName, source.Private, streamParams)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	sourceHierarchies, err := stream.Hierarchies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dimensions, columns, err := planCopyColumns(sourceHierarchies, targetDimensions, params.Mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without merged cells, write a batch of chunks whenever it fills
+	batchSize := 0
+	if !copyMergesCells(len(sourceHierarchies), columns) {
+		batchSize = max(params.Write.ChunkSize, 0)
+		if batchSize == 0 {
+			batchSize = DefaultWriteChunkSize
+		}
+		batchSize *= max(params.Write.Concurrency, 1)
+	}
+
+	report := &CopyDataReport{WriteReport: &WriteReport{}}
+	var coords [][]string
+	var values []interface{}
+	index := make(map[string]int)
+	written := 0
+	flush := func() error {
+		if len(coords) == 0 {
+			return nil
+		}
+		if params.Increment {
+			if err := target.addCurrentValues(ctx, targetCube, dimensions, coords, values, params); err != nil {
+				return err
+			}
+		}
+		batch, err := target.WriteValuesByCoordsChunked(ctx, targetCube, coords, values, dimensions, params.SandboxName, params.Write)
+		if err != nil {
+			return err
+		}
+		report.WriteReport.merge(batch, written)
+		written += len(coords)
+		coords, values = nil, nil
+		clear(index)
+		return batch.Err()
+	}
+
+	for stream.Next(ctx) {
+		record := stream.Record()
+		report.CellsRead++
+		if (params.SkipZeros && isZeroCellValue(record.Cell.Value)) ||
+			(params.SkipConsolidated && record.Cell.Consolidated) ||
+			(params.SkipRuleDerived && record.Cell.RuleDerived) {
+			report.CellsSkipped++
+			continue
+		}
+
+		elements, ok := mapCopyElements(record.Elements, columns)
+		if !ok {
+			report.CellsSkipped++
+			continue
+		}
+
+		key := normalizeCaseSpace(strings.Join(elements, "\x00"))
+		if i, seen := index[key]; seen {
+			values[i] = addCellValues(values[i], record.Value)
+			continue
+		}
+		index[key] = len(coords)
+		coords = append(coords, elements)
+		values = append(values, record.Value)
+
+		if batchSize > 0 && len(coords) >= batchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return report, err
+	}
+
+	if err := flush(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// copyMergesCells reports whether several source cells may end up on the same target cell: when a
+// source hierarchy is not copied to any target dimension, or a Lookup or Func may map several
+// elements to one.
+func copyMergesCells(sourceHierarchies int, columns []copyColumn) bool {
+	copied := make([]bool, sourceHierarchies)
+	for _, column := range columns {
+		if column.source == -1 {
+			continue
+		}
+		if column.mapping != nil && (column.lookup != nil || column.mapping.Func != nil) {
+			return true
+		}
+		copied[column.source] = true
+	}
+	for _, ok := range copied {
+		if !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// merge adds the report of a write that started at offset in the sequence of written cells.
+func (r *WriteReport) merge(other *WriteReport, offset int) {
+	for _, failed := range other.Failed {
+		failed.Index += r.Chunks
+		failed.Offset += offset
+		indexes := make([]int, len(failed.Indexes))
+		for i, index := range failed.Indexes {
+			indexes[i] = index + offset
+		}
+		failed.Indexes = indexes
+		r.Failed = append(r.Failed, failed)
+	}
+	for _, rejected := range other.Rejected {
+		rejected.Index += offset
+		r.Rejected = append(r.Rejected, rejected)
+	}
+	r.Chunks += other.Chunks
+	r.CellsWritten += other.CellsWritten
+}
+
+// planCopyColumns decides for each target dimension which source hierarchy or fixed element it
+// takes, and returns the target dimensions in "dimension:hierarchy" form where a mapping names a hierarchy.
+func planCopyColumns(sourceHierarchies, targetDimensions []string, mappings []CopyMapping) ([]string, []copyColumn, error) {
+	findSource := func(dimension string) int {
+		dim, hier := ExtractDimensionHierarchyFromString(dimension)
+		explicitHierarchy := dim != dimension
+		found := -1
+		for i, uniqueName := range sourceHierarchies {
+			sourceDim, sourceHier := ExtractDimensionHierarchyFromString(uniqueName)
+			if !caseAndSpaceInsensitiveEquals(sourceDim, dim) {
+				continue
+			}
+			if caseAndSpaceInsensitiveEquals(sourceHier, hier) {
+				return i
+			}
+			if !explicitHierarchy && found == -1 {
+				found = i
+			}
+		}
+		return found
+	}
+
+	dimensions := make([]string, len(targetDimensions))
+	columns := make([]copyColumn, len(targetDimensions))
+	for i, targetDimension := range targetDimensions {
+		dimensions[i] = targetDimension
+		columns[i] = copyColumn{source: -1}
+
+		for m := range mappings {
+			mapping := &mappings[m]
+			name := mapping.TargetDimension
+			if name == "" {
+				name = mapping.Dimension
+			}
+			dim, _ := ExtractDimensionHierarchyFromString(name)
+			if !caseAndSpaceInsensitiveEquals(dim, targetDimension) {
+				continue
+			}
+			if dim != name {
+				dimensions[i] = name
+			}
+			columns[i].mapping = mapping
+			if len(mapping.Lookup) > 0 {
+				columns[i].lookup = make(map[string]string, len(mapping.Lookup))
+				for from, to := range mapping.Lookup {
+					columns[i].lookup[normalizeCaseSpace(from)] = to
+				}
+			}
+			break
+		}
+
+		if columns[i].mapping != nil && columns[i].mapping.Element != "" {
+			continue
+		}
+		sourceDimension := targetDimension
+		if columns[i].mapping != nil && columns[i].mapping.Dimension != "" {
+			sourceDimension = columns[i].mapping.Dimension
+		}
+		if columns[i].source = findSource(sourceDimension); columns[i].source == -1 {
+			return nil, nil, fmt.Errorf("target dimension '%s' has no source: dimension '%s' is not on the source axes and no fixed element is mapped", targetDimension, sourceDimension)
+		}
+	}
+	return dimensions, columns, nil
+}
+
+// mapCopyElements returns the target elements of one source cell, or false if a mapping skips it.
+func mapCopyElements(sourceElements []string, columns []copyColumn) ([]string, bool) {
+	elements := make([]string, len(columns))
+	for i, column := range columns {
+		if column.source == -1 {
+			elements[i] = column.mapping.Element
+			continue
+		}
+		if column.source >= len(sourceElements) {
+			return nil, false
+		}
+		element := sourceElements[column.source]
+		if column.mapping == nil {
+			elements[i] = element
+			continue
+		}
+		if column.lookup != nil {
+			if mapped, ok := column.lookup[normalizeCaseSpace(element)]; ok {
+				element = mapped
+			} else if column.mapping.SkipUnmapped {
+				return nil, false
+			}
+		}
+		if column.mapping.Func != nil {
+			mapped, ok := column.mapping.Func(element)
+			if !ok {
+				return nil, false
+			}
+			element = mapped
+		}
+		elements[i] = element
+	}
+	return elements, true
+}
+
+// addCurrentValues adds the current target values to numeric copied values, one read per write chunk.
+func (cs *CellService) addCurrentValues(ctx context.Context, cubeName string, dimensions []string, coords [][]string, values []interface{}, params CopyDataParams) error {
+	chunkSize := params.Write.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultWriteChunkSize
+	}
+	for start := 0; start < len(coords); start += chunkSize {
+		end := min(start+chunkSize, len(coords))
+		tuples := make([][]MDXMember, 0, end-start)
+		for _, elements := range coords[start:end] {
+			tuple := make([]MDXMember, len(elements))
+			for i, element := range elements {
+				dim, hier, elem := resolveCoordinate(dimensions[i], element)
+				tuple[i] = NewMDXMember(dim, hier, elem)
+			}
+			tuples = append(tuples, tuple)
+		}
+
+		mdx, err := NewMDXQuery(cubeName).Columns(TuplesSet(tuples...)).Build()
+		if err != nil {
+			return err
+		}
+		cellset, err := cs.ExecuteMDX(ctx, mdx, []string{"Ordinal", "Value"}, params.SandboxName)
+		if err != nil {
+			return fmt.Errorf("read target values: %w", err)
+		}
+		for _, cell := range cellset.Cells {
+			if i := start + cell.Ordinal; i < end {
+				values[i] = addCellValues(cell.Value, values[i])
+			}
+		}
+	}
+	return nil
+}
+
+// addCellValues adds two numeric cell values, treating null as zero; otherwise the second value wins.
+func addCellValues(a, b interface{}) interface{} {
+	x, okA := cellNumber(a)
+	y, okB := cellNumber(b)
+	if okA && okB {
+		return x + y
+	}
+	return b
+}
+
+func cellNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case nil:
+		return 0, true
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
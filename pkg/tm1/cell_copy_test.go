@@ -0,0 +1,245 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestCellService(t *testing.T, handler http.HandlerFunc) *CellService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	rest, _ := NewRestService(Config{Address: "localhost", Port: 8882, SSL: false})
+	rest.SetBaseURL(server.URL)
+	return NewCellService(rest)
+}
+
+func TestCellService_CopyData(t *testing.T) {
+	source := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"src"}`))
+		case r.URL.Path == "/Cellsets('src')/Axes":
+			w.Write([]byte(`{"value":[
+				{"Ordinal":0,"Hierarchies":[{"UniqueName":"[Product].[Product]"}]},
+				{"Ordinal":1,"Hierarchies":[{"UniqueName":"[Version].[Version]"},{"UniqueName":"[Region].[Region]"}]}]}`))
+		case r.URL.Path == "/Cellsets('src')/Cells":
+			w.Write([]byte(`{"value":[
+				{"Ordinal":0,"Value":10,"Members":[{"Name":"P1"},{"Name":"Actual"},{"Name":"North"}]},
+				{"Ordinal":1,"Value":5,"Members":[{"Name":"P2"},{"Name":"actual"},{"Name":"North"}]},
+				{"Ordinal":2,"Value":0,"Members":[{"Name":"P1"},{"Name":"Actual"},{"Name":"South"}]},
+				{"Ordinal":3,"Value":99,"Members":[{"Name":"P1"},{"Name":"Budget"},{"Name":"North"}]},
+				{"Ordinal":4,"Value":7,"Members":[{"Name":"P1"},{"Name":"Actual"},{"Name":"Total"}]}]}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected source request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var mdx string
+	var updates []map[string]interface{}
+	target := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/Cubes('Plan')/Dimensions":
+			w.Write([]byte(`{"value":[{"Name":"Version"},{"Name":"Region"},{"Name":"Measure"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			mdx = body["MDX"]
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"cur"}`))
+		case r.URL.Path == "/Cellsets('cur')" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"Cells":[{"Ordinal":0,"Value":1}]}`))
+		case r.URL.Path == "/Cubes('Plan')/tm1.Update":
+			json.NewDecoder(r.Body).Decode(&updates)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected target request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	params := CopyDataParams{
+		Mappings: []CopyMapping{
+			{Dimension: "Version", Lookup: map[string]string{"Actual": "Forecast"}, SkipUnmapped: true},
+			{Dimension: "Region", Func: func(element string) (string, bool) { return strings.ToUpper(element), element != "Total" }},
+			{TargetDimension: "Measure", Element: "Amount"},
+		},
+		Target:    &TM1Service{Cells: target},
+		Increment: true,
+		SkipZeros: true,
+	}
+	report, err := source.CopyData(context.Background(), CopySource{MDX: "SELECT ..."}, "Plan", params)
+	if err != nil {
+		t.Fatalf("CopyData() failed: %v", err)
+	}
+	if report.CellsRead != 5 || report.CellsSkipped != 3 || report.CellsWritten != 1 {
+		t.Errorf("report = %+v", report)
+	}
+
+	if mdx != "SELECT {([Version].[Version].[Forecast],[Region].[Region].[NORTH],[Measure].[Measure].[Amount])} ON COLUMNS FROM [Plan]" {
+		t.Errorf("current values MDX = %s", mdx)
+	}
+	if len(updates) != 1 || updates[0]["Value"] != 16.0 {
+		t.Fatalf("updates = %v", updates)
+	}
+	binds := updates[0]["Cells"].([]interface{})[0].(map[string]interface{})["Tuple@odata.bind"].([]interface{})
+	if binds[0] != "Dimensions('Version')/Hierarchies('Version')/Elements('Forecast')" || binds[2] != "Dimensions('Measure')/Hierarchies('Measure')/Elements('Amount')" {
+		t.Errorf("bindings = %v", binds)
+	}
+}
+
+func TestPlanCopyColumnsMissingSource(t *testing.T) {
+	_, _, err := planCopyColumns([]string{"[Version].[Version]"}, []string{"Version", "Measure"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "target dimension 'Measure' has no source") {
+		t.Errorf("error = %v", err)
+	}
+
+	dimensions, columns, err := planCopyColumns(
+		[]string{"[Period].[Period]", "[Period].[Fiscal]"},
+		[]string{"Period"},
+		[]CopyMapping{{Dimension: "Period:Fiscal", TargetDimension: "Period:Fiscal"}},
+	)
+	if err != nil || dimensions[0] != "Period:Fiscal" || columns[0].source != 1 {
+		t.Errorf("dimensions = %v, columns = %+v, err = %v", dimensions, columns, err)
+	}
+}
+
+func TestCellService_CopyDataIncrementFlushesChunks(t *testing.T) {
+	source := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"src"}`))
+		case r.URL.Path == "/Cellsets('src')/Axes":
+			w.Write([]byte(`{"value":[{"Ordinal":0,"Hierarchies":[{"UniqueName":"[Region].[Region]"}]}]}`))
+		case r.URL.Path == "/Cellsets('src')/Cells":
+			w.Write([]byte(`{"value":[
+				{"Ordinal":0,"Value":10,"Members":[{"Name":"North"}]},
+				{"Ordinal":1,"Value":20,"Members":[{"Name":"South"}]},
+				{"Ordinal":2,"Value":30,"Members":[{"Name":"East"}]}]}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected source request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var requests []string
+	var written []interface{}
+	reads := 0
+	target := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/Cubes('Plan')/Dimensions":
+			w.Write([]byte(`{"value":[{"Name":"Region"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			requests = append(requests, "read "+body["MDX"])
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"ID":"cur%d"}`, reads)
+			reads++
+		case r.URL.Path == "/Cellsets('cur0')" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"Cells":[{"Ordinal":0,"Value":1},{"Ordinal":1,"Value":null}]}`))
+		case r.URL.Path == "/Cellsets('cur1')" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"Cells":[{"Ordinal":0,"Value":3}]}`))
+		case r.URL.Path == "/Cubes('Plan')/tm1.Update":
+			var updates []map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&updates)
+			requests = append(requests, fmt.Sprintf("write %d", len(updates)))
+			for _, update := range updates {
+				written = append(written, update["Value"])
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected target request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	params := CopyDataParams{
+		Target:    &TM1Service{Cells: target},
+		Increment: true,
+		Write:     WriteParams{ChunkSize: 2},
+	}
+	report, err := source.CopyData(context.Background(), CopySource{MDX: "SELECT ..."}, "Plan", params)
+	if err != nil {
+		t.Fatalf("CopyData() failed: %v", err)
+	}
+	if report.CellsRead != 3 || report.Chunks != 2 || report.CellsWritten != 3 {
+		t.Errorf("report = %+v, write report = %+v", report, report.WriteReport)
+	}
+
+	wantRequests := []string{
+		"read SELECT {([Region].[Region].[North]),([Region].[Region].[South])} ON COLUMNS FROM [Plan]",
+		"write 2",
+		"read SELECT {([Region].[Region].[East])} ON COLUMNS FROM [Plan]",
+		"write 1",
+	}
+	if strings.Join(requests, "\n") != strings.Join(wantRequests, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(wantRequests, "\n"))
+	}
+	if !reflect.DeepEqual(written, []interface{}{11.0, 20.0, 33.0}) {
+		t.Errorf("written values = %v", written)
+	}
+}
+
+func TestCellService_CopyDataStopsOnFailedChunk(t *testing.T) {
+	source := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"src"}`))
+		case r.URL.Path == "/Cellsets('src')/Axes":
+			w.Write([]byte(`{"value":[{"Ordinal":0,"Hierarchies":[{"UniqueName":"[Region].[Region]"}]}]}`))
+		case r.URL.Path == "/Cellsets('src')/Cells":
+			w.Write([]byte(`{"value":[
+				{"Ordinal":0,"Value":10,"Members":[{"Name":"North"}]},
+				{"Ordinal":1,"Value":20,"Members":[{"Name":"South"}]},
+				{"Ordinal":2,"Value":30,"Members":[{"Name":"East"}]}]}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected source request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	writes := 0
+	target := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/Cubes('Plan')/Dimensions":
+			w.Write([]byte(`{"value":[{"Name":"Region"}]}`))
+		case r.URL.Path == "/Cubes('Plan')/tm1.Update":
+			writes++
+			if writes == 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected target request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	params := CopyDataParams{Target: &TM1Service{Cells: target}, Write: WriteParams{ChunkSize: 1}}
+	report, err := source.CopyData(context.Background(), CopySource{MDX: "SELECT ..."}, "Plan", params)
+	if err == nil {
+		t.Fatal("CopyData() succeeded despite a failed chunk")
+	}
+	if report == nil {
+		t.Fatal("CopyData() returned no report with the error")
+	}
+	if writes != 2 || report.CellsWritten != 1 || len(report.Failed) != 1 || report.Failed[0].Coords[0][0] != "South" {
+		t.Errorf("writes = %d, report = %+v", writes, report.WriteReport)
+	}
+}
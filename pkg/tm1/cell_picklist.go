@@ -0,0 +1,322 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// picklistAttribute is the element attribute that holds element picklists.
+const picklistAttribute = "Picklist"
+
+// GetPicklist returns the picklist values of the cell at coordinates, given as one element per cube
+// dimension in cube order (see WriteValuesByCoords for qualified elements). It returns nil if the
+// cell has no picklist.
+//
+// The picklist is taken from the }PickList_<cube> control cube or, if that defines none for the
+// cell, from the Picklist attribute of the cell's elements, the first dimension in cube order
+// winning. Definitions may be static ("static:Red:Green", or "static|a:b|c" with another delimiter),
+// subset-based ("subset:dimension:subset" or "subset:dimension:hierarchy:subset") or
+// dimension-based ("dimension:dimension" or "dimension:dimension:hierarchy").
+func (cs *CellService) GetPicklist(ctx context.Context, cubeName string, coordinates []string) ([]string, error) {
+	dimensions, err := cs.getDimensionNamesForCube(ctx, cubeName)
+	if err != nil {
+		return nil, err
+	}
+	if len(coordinates) != len(dimensions) {
+		return nil, fmt.Errorf("coordinates have %d elements but cube '%s' has %d dimensions", len(coordinates), cubeName, len(dimensions))
+	}
+
+	picklists := newPicklistResolver(cs, cubeName, dimensions, "")
+	definitions, err := picklists.definitions(ctx, [][]string{coordinates})
+	if err != nil {
+		return nil, err
+	}
+	if definitions[0] == "" {
+		return nil, nil
+	}
+	return picklists.values(ctx, definitions[0])
+}
+
+// validatePicklists checks that every string value is on the picklist of its cell, if the cell has one.
+// Values are matched case- and space-insensitively, like element names, so "Not Started" matches a
+// "notstarted" picklist entry. Empty strings are accepted, as they clear the cell.
+func (cs *CellService) validatePicklists(ctx context.Context, cubeName string, coords [][]string, values []interface{}, dimensions []string, sandboxName string) error {
+	var indexes []int
+	var stringCoords [][]string
+	for i, value := range values {
+		if s, ok := value.(string); ok && s != "" {
+			indexes = append(indexes, i)
+			stringCoords = append(stringCoords, coords[i])
+		}
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	picklists := newPicklistResolver(cs, cubeName, dimensions, sandboxName)
+	definitions, err := picklists.definitions(ctx, stringCoords)
+	if err != nil {
+		return fmt.Errorf("get picklists: %w", err)
+	}
+	for n, definition := range definitions {
+		if definition == "" {
+			continue
+		}
+		value := values[indexes[n]].(string)
+		allowed, err := picklists.allows(ctx, definition, value)
+		if err != nil {
+			return fmt.Errorf("coordinate at index %d: %w", indexes[n], err)
+		}
+		if !allowed {
+			return fmt.Errorf("coordinate at index %d: value '%s' is not in picklist '%s'", indexes[n], value, definition)
+		}
+	}
+	return nil
+}
+
+// picklistResolver looks up the picklists of cells of one cube, caching control objects and
+// resolved definitions across cells.
+type picklistResolver struct {
+	cs          *CellService
+	cubeName    string
+	dimensions  []string
+	sandboxName string
+
+	loaded        bool
+	hasCube       bool                       // }PickList_<cube> exists
+	hasAttribute  []bool                     // Dimension has a Picklist attribute
+	elementValues map[string]string          // resolverKey(dimension, hierarchy) + element -> Picklist attribute
+	resolved      map[string][]string        // Definition -> values
+	normalized    map[string]map[string]bool // Definition -> normalizeCaseSpace(value) -> true
+}
+
+func newPicklistResolver(cs *CellService, cubeName string, dimensions []string, sandboxName string) *picklistResolver {
+	return &picklistResolver{
+		cs:            cs,
+		cubeName:      cubeName,
+		dimensions:    dimensions,
+		sandboxName:   sandboxName,
+		elementValues: make(map[string]string),
+		resolved:      make(map[string][]string),
+		normalized:    make(map[string]map[string]bool),
+	}
+}
+
+// load checks once which control objects define picklists for the cube.
+func (pr *picklistResolver) load(ctx context.Context) error {
+	if pr.loaded {
+		return nil
+	}
+
+	var err error
+	pr.hasCube, err = NewCubeService(pr.cs.rest).Exists(ctx, "}PickList_"+pr.cubeName)
+	if err != nil {
+		return fmt.Errorf("check picklist cube: %w", err)
+	}
+
+	elements := NewElementService(pr.cs.rest)
+	pr.hasAttribute = make([]bool, len(pr.dimensions))
+	for i, dimension := range pr.dimensions {
+		dim, hier, _ := resolveCoordinate(dimension, "")
+		attributes, err := elements.GetElementAttributes(ctx, dim, hier)
+		if err != nil {
+			return err
+		}
+		for _, attribute := range attributes {
+			if caseAndSpaceInsensitiveEquals(attribute.Name, picklistAttribute) {
+				pr.hasAttribute[i] = true
+				break
+			}
+		}
+	}
+
+	pr.loaded = true
+	return nil
+}
+
+// definitions returns the picklist definition of each cell, or "" for cells without a picklist.
+func (pr *picklistResolver) definitions(ctx context.Context, coords [][]string) ([]string, error) {
+	if err := pr.load(ctx); err != nil {
+		return nil, err
+	}
+
+	definitions := make([]string, len(coords))
+	if pr.hasCube {
+		if err := pr.readCubeDefinitions(ctx, coords, definitions); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, hasAttribute := range pr.hasAttribute {
+		if !hasAttribute {
+			continue
+		}
+		if err := pr.readAttributeDefinitions(ctx, i, coords, definitions); err != nil {
+			return nil, err
+		}
+	}
+	return definitions, nil
+}
+
+// readCubeDefinitions reads the definitions of the cells from }PickList_<cube>, one query per write chunk.
+func (pr *picklistResolver) readCubeDefinitions(ctx context.Context, coords [][]string, definitions []string) error {
+	pickCube := "}PickList_" + pr.cubeName
+	for start := 0; start < len(coords); start += DefaultWriteChunkSize {
+		end := min(start+DefaultWriteChunkSize, len(coords))
+		tuples := make([][]MDXMember, 0, end-start)
+		for _, elements := range coords[start:end] {
+			tuple := make([]MDXMember, 0, len(elements)+1)
+			for i, element := range elements {
+				dim, hier, elem := resolveCoordinate(pr.dimensions[i], element)
+				tuple = append(tuple, NewMDXMember(dim, hier, elem))
+			}
+			tuples = append(tuples, append(tuple, NewMDXMember("}PickList", "}PickList", "Value")))
+		}
+
+		mdx, err := NewMDXQuery(pickCube).Columns(TuplesSet(tuples...)).Build()
+		if err != nil {
+			return err
+		}
+		cellset, err := pr.cs.ExecuteMDX(ctx, mdx, []string{"Ordinal", "Value"}, pr.sandboxName)
+		if err != nil {
+			return fmt.Errorf("read picklist cube: %w", err)
+		}
+		for _, cell := range cellset.Cells {
+			if i := start + cell.Ordinal; i < end {
+				if definition, ok := cell.Value.(string); ok {
+					definitions[i] = strings.TrimSpace(definition)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// readAttributeDefinitions fills the definitions still empty from the Picklist attribute of the
+// elements of one dimension, reading each element once.
+func (pr *picklistResolver) readAttributeDefinitions(ctx context.Context, dimensionIndex int, coords [][]string, definitions []string) error {
+	dim, hier, _ := resolveCoordinate(pr.dimensions[dimensionIndex], "")
+	prefix := resolverKey(dim, hier)
+
+	var pending []string
+	seen := make(map[string]bool)
+	for i, elements := range coords {
+		if definitions[i] != "" {
+			continue
+		}
+		_, _, elem := resolveCoordinate(pr.dimensions[dimensionIndex], elements[dimensionIndex])
+		key := prefix + normalizeCaseSpace(elem)
+		if _, cached := pr.elementValues[key]; cached || seen[key] {
+			continue
+		}
+		seen[key] = true
+		pending = append(pending, elem)
+	}
+
+	attributeCube := "}ElementAttributes_" + dim
+	attributeMember := NewMDXMember(attributeCube, attributeCube, picklistAttribute)
+	for start := 0; start < len(pending); start += DefaultWriteChunkSize {
+		end := min(start+DefaultWriteChunkSize, len(pending))
+		tuples := make([][]MDXMember, 0, end-start)
+		for _, elem := range pending[start:end] {
+			tuples = append(tuples, []MDXMember{NewMDXMember(dim, hier, elem), attributeMember})
+		}
+
+		mdx, err := NewMDXQuery(attributeCube).Columns(TuplesSet(tuples...)).Build()
+		if err != nil {
+			return err
+		}
+		cellset, err := pr.cs.ExecuteMDX(ctx, mdx, []string{"Ordinal", "Value"}, "")
+		if err != nil {
+			return fmt.Errorf("read picklist attribute of dimension '%s': %w", dim, err)
+		}
+		for _, elem := range pending[start:end] {
+			pr.elementValues[prefix+normalizeCaseSpace(elem)] = ""
+		}
+		for _, cell := range cellset.Cells {
+			if i := start + cell.Ordinal; i < end {
+				if definition, ok := cell.Value.(string); ok {
+					pr.elementValues[prefix+normalizeCaseSpace(pending[i])] = strings.TrimSpace(definition)
+				}
+			}
+		}
+	}
+
+	for i, elements := range coords {
+		if definitions[i] == "" {
+			_, _, elem := resolveCoordinate(pr.dimensions[dimensionIndex], elements[dimensionIndex])
+			definitions[i] = pr.elementValues[prefix+normalizeCaseSpace(elem)]
+		}
+	}
+	return nil
+}
+
+// allows reports whether value is on the picklist, ignoring case and spaces.
+func (pr *picklistResolver) allows(ctx context.Context, definition, value string) (bool, error) {
+	set, ok := pr.normalized[definition]
+	if !ok {
+		values, err := pr.values(ctx, definition)
+		if err != nil {
+			return false, err
+		}
+		set = make(map[string]bool, len(values))
+		for _, v := range values {
+			set[normalizeCaseSpace(v)] = true
+		}
+		pr.normalized[definition] = set
+	}
+	return set[normalizeCaseSpace(value)], nil
+}
+
+// values resolves a picklist definition to its values.
+func (pr *picklistResolver) values(ctx context.Context, definition string) ([]string, error) {
+	if values, ok := pr.resolved[definition]; ok {
+		return values, nil
+	}
+
+	kind, rest, _ := strings.Cut(definition, ":")
+	var values []string
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "dimension":
+		parts := strings.Split(rest, ":")
+		if len(parts) > 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid picklist '%s'", definition)
+		}
+		hier := parts[len(parts)-1]
+		names, err := NewElementService(pr.cs.rest).GetElementNames(ctx, parts[0], hier)
+		if err != nil {
+			return nil, fmt.Errorf("resolve picklist '%s': %w", definition, err)
+		}
+		values = names
+
+	case "subset":
+		parts := strings.Split(rest, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid picklist '%s'", definition)
+		}
+		dim, hier, subset := parts[0], parts[0], parts[len(parts)-1]
+		if len(parts) == 3 {
+			hier = parts[1]
+		}
+		axis, err := NewElementService(pr.cs.rest).ExecuteSetMDX(ctx, MDXExecuteParams{MDX: TM1SubsetToSet(dim, hier, subset).MDX()})
+		if err != nil {
+			return nil, fmt.Errorf("resolve picklist '%s': %w", definition, err)
+		}
+		for _, tuple := range axis.Tuples {
+			if len(tuple.Members) > 0 {
+				values = append(values, tuple.Members[0].Name)
+			}
+		}
+
+	default:
+		// Static picklists are "static" followed by a delimiter, ':' unless another character is used.
+		if len(definition) < 7 || !strings.EqualFold(definition[:6], "static") {
+			return nil, fmt.Errorf("invalid picklist '%s'", definition)
+		}
+		values = strings.Split(definition[7:], definition[6:7])
+	}
+
+	pr.resolved[definition] = values
+	return values, nil
+}
@@ -0,0 +1,148 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newPicklistTestHandler(t *testing.T, pickCube bool, mdx *[]string, updates *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/Cubes('Sales')/Dimensions":
+			w.Write([]byte(`{"value":[{"Name":"Version"},{"Name":"Color"}]}`))
+		case r.URL.Path == "/Cubes('}PickList_Sales')":
+			if !pickCube {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"Name":"}PickList_Sales"}`))
+		case r.URL.Path == "/Dimensions('Version')/Hierarchies('Version')/ElementAttributes":
+			w.Write([]byte(`{"value":[]}`))
+		case r.URL.Path == "/Dimensions('Color')/Hierarchies('Color')/ElementAttributes":
+			w.Write([]byte(`{"value":[{"Name":"Picklist","Type":"String"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			*mdx = append(*mdx, body["MDX"])
+			id := "attr"
+			if strings.Contains(body["MDX"], "FROM [}PickList_Sales]") {
+				id = "pick"
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"` + id + `"}`))
+		case r.URL.Path == "/Cellsets('pick')" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"Cells":[{"Ordinal":0,"Value":"static|a:b|c"},{"Ordinal":1,"Value":""}]}`))
+		case r.URL.Path == "/Cellsets('attr')" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"Cells":[{"Ordinal":0,"Value":"subset:Color:Basic"}]}`))
+		case r.URL.Path == "/ExecuteMDXSetExpression":
+			w.Write([]byte(`{"Tuples":[{"Members":[{"Name":"Red"}]},{"Members":[{"Name":"Green"}]}]}`))
+		case r.URL.Path == "/Cubes('Sales')/tm1.Update":
+			*updates++
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestCellServiceGetPicklist(t *testing.T) {
+	var mdx []string
+	var updates int
+	cells := newTestCellService(t, newPicklistTestHandler(t, true, &mdx, &updates))
+
+	values, err := cells.GetPicklist(context.Background(), "Sales", []string{"Actual", "Red"})
+	if err != nil {
+		t.Fatalf("GetPicklist() failed: %v", err)
+	}
+	if !reflect.DeepEqual(values, []string{"a:b", "c"}) {
+		t.Errorf("values = %v", values)
+	}
+	want := "SELECT {([Version].[Version].[Actual],[Color].[Color].[Red],[}PickList].[}PickList].[Value])} ON COLUMNS FROM [}PickList_Sales]"
+	if len(mdx) != 1 || mdx[0] != want {
+		t.Errorf("mdx = %v", mdx)
+	}
+
+	mdx = nil
+	cells = newTestCellService(t, newPicklistTestHandler(t, false, &mdx, &updates))
+	values, err = cells.GetPicklist(context.Background(), "Sales", []string{"Actual", "Red"})
+	if err != nil {
+		t.Fatalf("GetPicklist() failed: %v", err)
+	}
+	if !reflect.DeepEqual(values, []string{"Red", "Green"}) {
+		t.Errorf("values = %v", values)
+	}
+	want = "SELECT {([Color].[Color].[Red],[}ElementAttributes_Color].[}ElementAttributes_Color].[Picklist])} ON COLUMNS FROM [}ElementAttributes_Color]"
+	if len(mdx) != 1 || mdx[0] != want {
+		t.Errorf("mdx = %v", mdx)
+	}
+}
+
+func TestPicklistResolverStaticDefinitions(t *testing.T) {
+	picklists := newPicklistResolver(nil, "Sales", nil, "")
+	tests := map[string][]string{
+		"static:Red:Green": {"Red", "Green"},
+		"Static|a:b|c":     {"a:b", "c"},
+		"static:":          {""},
+	}
+	for definition, want := range tests {
+		got, err := picklists.values(context.Background(), definition)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("values(%q) = %v, %v, want %v", definition, got, err, want)
+		}
+	}
+	for _, definition := range []string{"static", "subset:Color", "dimension:", "list:a:b"} {
+		if _, err := picklists.values(context.Background(), definition); err == nil {
+			t.Errorf("values(%q) succeeded", definition)
+		}
+	}
+}
+
+func TestCellServiceWriteStrictPicklists(t *testing.T) {
+	var mdx []string
+	var updates int
+	cells := newTestCellService(t, newPicklistTestHandler(t, false, &mdx, &updates))
+
+	params := WriteParams{StrictPicklists: true}
+	coords := [][]string{{"Actual", "Red"}, {"Actual", "Blue"}}
+	_, err := cells.WriteValuesByCoordsChunked(context.Background(), "Sales", coords, []interface{}{"Green", 5}, nil, "", params)
+	if err != nil {
+		t.Fatalf("WriteValuesByCoordsChunked() failed: %v", err)
+	}
+
+	_, err = cells.WriteValuesByCoordsChunked(context.Background(), "Sales", coords, []interface{}{"Blue", ""}, nil, "", params)
+	if err == nil || !strings.Contains(err.Error(), "coordinate at index 0: value 'Blue' is not in picklist 'subset:Color:Basic'") {
+		t.Errorf("error = %v", err)
+	}
+	if updates != 1 {
+		t.Errorf("updates = %d, want only the valid write", updates)
+	}
+}
+
+func TestCellServiceWriteValuesWithParamsStrictPicklists(t *testing.T) {
+	var mdx []string
+	var updates int
+	cells := newTestCellService(t, newPicklistTestHandler(t, false, &mdx, &updates))
+
+	params := WriteParams{StrictPicklists: true}
+	report, err := cells.WriteValuesWithParams(context.Background(), "Sales", map[string]interface{}{"Actual, Red": " g reen"}, nil, "", params)
+	if err != nil {
+		t.Fatalf("WriteValuesWithParams() failed: %v", err)
+	}
+	if report.CellsWritten != 1 || updates != 1 {
+		t.Errorf("CellsWritten = %d, updates = %d", report.CellsWritten, updates)
+	}
+
+	_, err = cells.WriteValuesWithParams(context.Background(), "Sales", map[string]interface{}{"Actual,Red": "Blue"}, nil, "", params)
+	if err == nil || !strings.Contains(err.Error(), "value 'Blue' is not in picklist") {
+		t.Errorf("error = %v", err)
+	}
+	if updates != 1 {
+		t.Errorf("updates = %d, want only the valid write", updates)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/andreyea/tm1go/pkg/models"
 	"github.com/go-gota/gota/dataframe"
@@ -43,7 +45,7 @@ type Cellset struct {
 	Cube    *models.Cube                      `json:"Cube,omitempty"`
 	Axes    []Axis                            `json:"Axes,omitempty"`
 	Cells   []Cell                            `json:"Cells,omitempty"`
-	CellMap map[string]map[string]interface{} `json:"-"` // Coordinate tuple -> cell properties
+	CellMap map[string]map[string]interface{} `json:"-"` // Coordinate tuple -> cell properties; see Cell and Lookup for typed access
 }
 
 // Axis represents an axis in a cellset
@@ -253,17 +255,29 @@ type Drillthrough struct {
 
 // Annotation represents a cell annotation.
 type Annotation struct {
-	ID            string `json:"ID"`
-	Text          string `json:"Text,omitempty"`
-	Creator       string `json:"Creator,omitempty"`
-	Created       string `json:"Created,omitempty"`
-	LastUpdatedBy string `json:"LastUpdatedBy,omitempty"`
-	LastUpdated   string `json:"LastUpdated,omitempty"`
+	ID                 string   `json:"ID"`
+	Text               string   `json:"Text,omitempty"`
+	Creator            string   `json:"Creator,omitempty"`
+	Created            string   `json:"Created,omitempty"`
+	LastUpdatedBy      string   `json:"LastUpdatedBy,omitempty"`
+	LastUpdated        string   `json:"LastUpdated,omitempty"`
+	ObjectName         string   `json:"objectName,omitempty"`         // Cube name
+	DimensionalContext []Member `json:"DimensionalContext,omitempty"` // Annotated cell, one member per cube dimension
+}
+
+// Elements returns the element names of the annotated cell in cube dimension order.
+func (a Annotation) Elements() []string {
+	elements := make([]string, len(a.DimensionalContext))
+	for i, member := range a.DimensionalContext {
+		elements[i] = member.Name
+	}
+	return elements
 }
 
 // GetValue returns a single cube value from specified coordinates
 // elements can be a slice of element names in the correct dimension order
 // dimensions should contain the dimension names in their natural order
+// Elements and dimensions may address alternate hierarchies, see WriteValuesByCoords.
 func (cs *CellService) GetValue(ctx context.Context, cubeName string, elements []string, dimensions []string, sandboxName string) (interface{}, error) {
 	if len(elements) == 0 {
 		return nil, fmt.Errorf("elements cannot be empty")
@@ -286,9 +300,9 @@ func (cs *CellService) GetValue(ctx context.Context, cubeName string, elements [
 	// SELECT {} ON ROWS, {} ON COLUMNS FROM [cube]
 	// Only the last element is used as the MDX ON COLUMN statement
 	mdxParts := make([]string, 0, len(elements))
-	for i, elem := range elements {
-		dim := dimensions[i]
-		mdxParts = append(mdxParts, fmt.Sprintf("[%s].[%s].[%s]", dim, dim, elem))
+	for i, element := range elements {
+		dim, hier, elem := resolveCoordinate(dimensions[i], element)
+		mdxParts = append(mdxParts, models.QuoteMDXName(dim)+"."+models.QuoteMDXName(hier)+"."+models.QuoteMDXName(elem))
 	}
 
 	var mdxRows, mdxColumns string
@@ -300,7 +314,7 @@ func (cs *CellService) GetValue(ctx context.Context, cubeName string, elements [
 		mdxColumns = mdxParts[0]
 	}
 
-	mdx := fmt.Sprintf("SELECT %s ON ROWS, %s ON COLUMNS FROM [%s]", mdxRows, mdxColumns, cubeName)
+	mdx := fmt.Sprintf("SELECT %s ON ROWS, %s ON COLUMNS FROM %s", mdxRows, mdxColumns, models.QuoteMDXName(cubeName))
 
 	// Execute MDX
 	cellset, err := cs.ExecuteMDX(ctx, mdx, nil, sandboxName)
@@ -352,6 +366,63 @@ func (cs *CellService) ExecuteView(ctx context.Context, cubeName, viewName strin
 	return cellset, nil
 }
 
+// GetDrillthroughScripts returns the names of the drillthrough scripts available for a cell.
+// The cellset must still exist on the server: extract it with deleteCellset set to false.
+func (cs *CellService) GetDrillthroughScripts(ctx context.Context, cellsetID string, ordinal int, sandboxName string) ([]string, error) {
+	endpoint := fmt.Sprintf("/Cellsets('%s')/Cells(%d)/DrillthroughScripts?$select=Name", cellsetID, ordinal)
+	if sandboxName != "" {
+		endpoint = addSandboxParam(endpoint, sandboxName)
+	}
+
+	var result struct {
+		Value []Drillthrough `json:"value"`
+	}
+	if err := cs.rest.JSON(ctx, http.MethodGet, endpoint, nil, &result); err != nil {
+		return nil, fmt.Errorf("get drillthrough scripts: %w", err)
+	}
+
+	names := make([]string, len(result.Value))
+	for i, script := range result.Value {
+		names[i] = script.Name
+	}
+	return names, nil
+}
+
+// CreateDrillthroughCellset executes a drillthrough script on a cell and returns the ID of the
+// resulting cellset. The source cellset must still exist on the server.
+func (cs *CellService) CreateDrillthroughCellset(ctx context.Context, cellsetID string, ordinal int, scriptName string, sandboxName string) (string, error) {
+	endpoint := fmt.Sprintf("/Cellsets('%s')/Cells(%d)/DrillthroughScripts('%s')/tm1.Execute",
+		cellsetID, ordinal, escapeODataKey(scriptName))
+	if sandboxName != "" {
+		endpoint = addSandboxParam(endpoint, sandboxName)
+	}
+
+	var result struct {
+		ID string `json:"ID"`
+	}
+	if err := cs.rest.JSON(ctx, http.MethodPost, endpoint, nil, &result); err != nil {
+		return "", fmt.Errorf("execute drillthrough: %w", err)
+	}
+	return result.ID, nil
+}
+
+// ExecuteDrillthrough executes a drillthrough script on the cell with the given ordinal and returns
+// the resulting cellset. Cells offering drillthrough have HasDrillthrough set; their scripts are
+// listed in DrillthroughScripts or by GetDrillthroughScripts. The source cellset must still exist on
+// the server, while the drillthrough cellset is deleted after extraction.
+func (cs *CellService) ExecuteDrillthrough(ctx context.Context, cellsetID string, ordinal int, scriptName string, cellProperties []string, sandboxName string) (*Cellset, error) {
+	drillCellsetID, err := cs.CreateDrillthroughCellset(ctx, cellsetID, ordinal, scriptName, sandboxName)
+	if err != nil {
+		return nil, err
+	}
+
+	cellset, err := cs.ExtractCellset(ctx, drillCellsetID, cellProperties, true, sandboxName)
+	if err != nil {
+		return nil, fmt.Errorf("extract cellset: %w", err)
+	}
+	return cellset, nil
+}
+
 // WriteValue writes a single value to a cube at the specified coordinates
 func (cs *CellService) WriteValue(ctx context.Context, cubeName string, elements []string, dimensions []string, value interface{}, sandboxName string) error {
 	if len(elements) == 0 {
@@ -371,27 +442,18 @@ func (cs *CellService) WriteValue(ctx context.Context, cubeName string, elements
 		return fmt.Errorf("elements count (%d) must match dimensions count (%d)", len(elements), len(dimensions))
 	}
 
-	// Build OData tuple
-	tupleBindings := make([]string, 0, len(elements))
-	for i, elem := range elements {
-		dim := dimensions[i]
-		hier := dim // Default hierarchy has same name as dimension
-		tupleBindings = append(tupleBindings, fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-			url.PathEscape(dim), url.PathEscape(hier), url.PathEscape(elem)))
-	}
-
 	// Build request body
 	body := map[string]interface{}{
 		"Cells": []map[string]interface{}{
 			{
-				"Tuple@odata.bind": tupleBindings,
+				"Tuple@odata.bind": composeTupleBindings(elements, dimensions),
 			},
 		},
 		"Value": value,
 	}
 
 	// Build URL
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Update", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Update", escapeODataKey(cubeName))
 	if sandboxName != "" {
 		endpoint = addSandboxParam(endpoint, sandboxName)
 	}
@@ -415,37 +477,149 @@ func (cs *CellService) WriteValue(ctx context.Context, cubeName string, elements
 // WriteValues writes multiple cell values to a cube using comma-separated coordinates.
 // cells is a map where keys are coordinate tuples (comma-separated element names) and values are the cell values.
 // dimensions should contain the dimension names in their natural order.
+// To validate string values against picklists or skip cells that are not updateable, use WriteValuesWithParams.
+// If a chunk fails to write, the error of the first failed chunk is returned.
 func (cs *CellService) WriteValues(ctx context.Context, cubeName string, cells map[string]interface{}, dimensions []string, sandboxName string) error {
-	if len(cells) == 0 {
-		return nil // Nothing to write
+	report, err := cs.WriteValuesWithParams(ctx, cubeName, cells, dimensions, sandboxName, WriteParams{})
+	if err != nil {
+		return err
+	}
+	if len(report.Failed) > 0 {
+		return report.Failed[0]
 	}
+	return nil
+}
 
+// WriteValuesWithParams writes cells like WriteValues, controlled by params like WriteValuesByCoordsChunked.
+// Cells are written in no particular order, so the report identifies rejected cells and failed chunks by
+// their keys in cells (RejectedCell.Key and WriteChunkError.Keys) rather than by index.
+func (cs *CellService) WriteValuesWithParams(ctx context.Context, cubeName string, cells map[string]interface{}, dimensions []string, sandboxName string, params WriteParams) (*WriteReport, error) {
+	keys := make([]string, 0, len(cells))
 	coords := make([][]string, 0, len(cells))
 	values := make([]interface{}, 0, len(cells))
-
 	for coordKey, value := range cells {
 		// Parse coordinate key (comma-separated format)
 		elements := strings.Split(coordKey, ",")
 		for i := range elements {
 			elements[i] = strings.TrimSpace(elements[i])
 		}
+		keys = append(keys, coordKey)
 		coords = append(coords, elements)
 		values = append(values, value)
 	}
 
-	return cs.WriteValuesByCoords(ctx, cubeName, coords, values, dimensions, sandboxName)
+	report, err := cs.WriteValuesByCoordsChunked(ctx, cubeName, coords, values, dimensions, sandboxName, params)
+	if err != nil {
+		return nil, err
+	}
+	for i := range report.Rejected {
+		report.Rejected[i].Key = keys[report.Rejected[i].Index]
+	}
+	for i := range report.Failed {
+		failed := &report.Failed[i]
+		failed.Keys = make([]string, len(failed.Indexes))
+		for j, index := range failed.Indexes {
+			failed.Keys[j] = keys[index]
+		}
+	}
+	return report, nil
 }
 
 // WriteValuesByCoords writes multiple cell values to a cube using explicit coordinates.
 // coords is a slice of element tuples (one tuple per cell), and values is the matching list of values.
 // dimensions should contain the dimension names in their natural order.
+// A dimension may name a hierarchy as "dimension:hierarchy" or "[dimension].[hierarchy]", and an
+// element may be qualified as "dimension:hierarchy:element" or "[dimension].[hierarchy].[element]";
+// otherwise the default hierarchy of the dimension is used.
+// Cells are sent in chunks of DefaultWriteChunkSize as one tm1.Update request per chunk.
 func (cs *CellService) WriteValuesByCoords(ctx context.Context, cubeName string, coords [][]string, values []interface{}, dimensions []string, sandboxName string) error {
-	if len(coords) == 0 {
+	report, err := cs.WriteValuesByCoordsChunked(ctx, cubeName, coords, values, dimensions, sandboxName, WriteParams{})
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// DefaultWriteChunkSize is the number of cells sent per tm1.Update request when WriteParams.ChunkSize is not set.
+const DefaultWriteChunkSize = 1000
+
+// WriteParams controls how cell writes are split into requests.
+type WriteParams struct {
+	ChunkSize   int                // Number of cells per tm1.Update request (default: DefaultWriteChunkSize)
+	Concurrency int                // Number of chunks written in parallel (default: 1)
+	Resolvers   []*ElementResolver // Resolve coordinates of these hierarchies to principal names before writing
+
+	// StrictPicklists rejects the write before sending anything if a string value is not on the
+	// picklist of its cell, matching case- and space-insensitively like element names. See GetPicklist.
+	StrictPicklists bool
+
+	// CheckUpdateable reads the Updateable property of the target cells before writing, and leaves
+	// out cells that are consolidated, rule-calculated, restricted by security or locked, listing
+	// them in WriteReport.Rejected instead of failing their chunk.
+	CheckUpdateable bool
+}
+
+// WriteReport summarises the outcome of a chunked cell write.
+type WriteReport struct {
+	Chunks       int               // Number of chunks sent
+	CellsWritten int               // Number of cells in successfully written chunks
+	Failed       []WriteChunkError // Chunks that could not be written, ordered by chunk index
+	Rejected     []RejectedCell    // Cells left out by WriteParams.CheckUpdateable, ordered by index
+}
+
+// WriteChunkError describes a chunk that failed to write.
+// Coords and Values hold the chunk contents so the write can be retried.
+type WriteChunkError struct {
+	Index   int      // Chunk index
+	Offset  int      // Position of the first chunk cell in the original coords and values
+	Indexes []int    // Position of each chunk cell in the original coords and values; not contiguous if cells were rejected
+	Keys    []string // Coordinate key of each chunk cell, set by WriteValuesWithParams
+	Coords  [][]string
+	Values  []interface{}
+	Err     error
+}
+
+// Error implements error.
+func (e WriteChunkError) Error() string {
+	if len(e.Keys) > 0 {
+		return fmt.Sprintf("chunk %d (%d cells from %q): %v", e.Index, len(e.Keys), e.Keys[0], e.Err)
+	}
+	last := e.Offset + len(e.Coords) - 1
+	if len(e.Indexes) > 0 {
+		last = e.Indexes[len(e.Indexes)-1]
+	}
+	return fmt.Sprintf("chunk %d (cells %d-%d): %v", e.Index, e.Offset, last, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e WriteChunkError) Unwrap() error {
+	return e.Err
+}
+
+// Err returns an error describing all failed chunks, or nil if every chunk was written.
+func (r *WriteReport) Err() error {
+	if r == nil || len(r.Failed) == 0 {
 		return nil
 	}
+	errs := make([]error, len(r.Failed))
+	for i := range r.Failed {
+		errs[i] = r.Failed[i]
+	}
+	return fmt.Errorf("%d of %d chunks failed to write: %w", len(r.Failed), r.Chunks, errors.Join(errs...))
+}
+
+// WriteValuesByCoordsChunked writes multiple cell values to a cube using explicit coordinates,
+// sending one tm1.Update request per chunk of cells.
+// Chunks are written independently: a failing chunk does not stop the others, and is listed
+// in the returned report. The returned error is only set for problems detected before writing.
+func (cs *CellService) WriteValuesByCoordsChunked(ctx context.Context, cubeName string, coords [][]string, values []interface{}, dimensions []string, sandboxName string, params WriteParams) (*WriteReport, error) {
+	report := &WriteReport{}
+	if len(coords) == 0 {
+		return report, nil
+	}
 
 	if len(coords) != len(values) {
-		return fmt.Errorf("coords count (%d) must match values count (%d)", len(coords), len(values))
+		return nil, fmt.Errorf("coords count (%d) must match values count (%d)", len(coords), len(values))
 	}
 
 	if len(dimensions) == 0 {
@@ -453,59 +627,198 @@ func (cs *CellService) WriteValuesByCoords(ctx context.Context, cubeName string,
 		var err error
 		dimensions, err = cs.getDimensionNamesForCube(ctx, cubeName)
 		if err != nil {
-			return fmt.Errorf("get dimensions: %w", err)
+			return nil, fmt.Errorf("get dimensions: %w", err)
 		}
 	}
 
 	// Build an array of cell updates
 	cellUpdates := make([]map[string]interface{}, 0, len(coords))
+	resolvers := newElementResolvers(params.Resolvers)
 
 	for i, elements := range coords {
 		if len(elements) != len(dimensions) {
-			return fmt.Errorf("coordinate at index %d has %d elements but expected %d dimensions", i, len(elements), len(dimensions))
+			return nil, fmt.Errorf("coordinate at index %d has %d elements but expected %d dimensions", i, len(elements), len(dimensions))
 		}
 
-		// Build tuple bindings for this cell
-		tupleBindings := make([]string, 0, len(elements))
-		for j, elem := range elements {
-			dim := dimensions[j]
-			hier := dim // Default hierarchy
-			tupleBindings = append(tupleBindings, fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-				url.PathEscape(dim), url.PathEscape(hier), url.PathEscape(elem)))
+		tupleBindings, err := composeResolvedTupleBindings(elements, dimensions, resolvers)
+		if err != nil {
+			return nil, fmt.Errorf("coordinate at index %d: %w", i, err)
 		}
 
-		cellUpdate := map[string]interface{}{
+		cellUpdates = append(cellUpdates, map[string]interface{}{
 			"Cells": []map[string]interface{}{
 				{
 					"Tuple@odata.bind": tupleBindings,
 				},
 			},
 			"Value": values[i],
+		})
+	}
+
+	if params.StrictPicklists {
+		if err := cs.validatePicklists(ctx, cubeName, coords, values, dimensions, sandboxName); err != nil {
+			return nil, err
+		}
+	}
+
+	chunkSize := params.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultWriteChunkSize
+	}
+
+	var indexes []int
+	if params.CheckUpdateable {
+		reasons, err := cs.checkUpdateable(ctx, cubeName, coords, dimensions, sandboxName, chunkSize)
+		if err != nil {
+			return nil, err
+		}
+		// indexes maps the cells left to write to their position in the original coords and values
+		n := 0
+		indexes = make([]int, 0, len(coords))
+		writeCoords := make([][]string, 0, len(coords))
+		writeValues := make([]interface{}, 0, len(values))
+		for i, reason := range reasons {
+			if reason != "" {
+				report.Rejected = append(report.Rejected, RejectedCell{Index: i, Coords: coords[i], Value: values[i], Reason: reason})
+				continue
+			}
+			cellUpdates[n] = cellUpdates[i]
+			n++
+			indexes = append(indexes, i)
+			writeCoords = append(writeCoords, coords[i])
+			writeValues = append(writeValues, values[i])
+		}
+		cellUpdates, coords, values = cellUpdates[:n], writeCoords, writeValues
+		if n == 0 {
+			return report, nil
 		}
-		cellUpdates = append(cellUpdates, cellUpdate)
 	}
 
 	// Build URL
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Update", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Update", escapeODataKey(cubeName))
 	if sandboxName != "" {
 		endpoint = addSandboxParam(endpoint, sandboxName)
 	}
 
-	// Execute each update (could be optimized with batch requests)
-	for _, cellUpdate := range cellUpdates {
-		payload, err := json.Marshal(cellUpdate)
-		if err != nil {
-			return fmt.Errorf("marshal cell update: %w", err)
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	report.Chunks = (len(cellUpdates) + chunkSize - 1) / chunkSize
+	errs := make([]error, report.Chunks)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for chunk := 0; chunk < report.Chunks; chunk++ {
+		if ctx.Err() != nil {
+			errs[chunk] = ctx.Err()
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(chunk int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := chunk * chunkSize
+			end := min(start+chunkSize, len(cellUpdates))
+			errs[chunk] = cs.postCellUpdates(ctx, endpoint, cellUpdates[start:end])
+		}(chunk)
+	}
+	wg.Wait()
+
+	for chunk, err := range errs {
+		start := chunk * chunkSize
+		end := min(start+chunkSize, len(cellUpdates))
+		if err == nil {
+			report.CellsWritten += end - start
+			continue
+		}
+		chunkIndexes := make([]int, end-start)
+		for i := range chunkIndexes {
+			chunkIndexes[i] = start + i
+			if indexes != nil {
+				chunkIndexes[i] = indexes[start+i]
+			}
 		}
+		report.Failed = append(report.Failed, WriteChunkError{
+			Index:   chunk,
+			Offset:  chunkIndexes[0],
+			Indexes: chunkIndexes,
+			Coords:  coords[start:end],
+			Values:  values[start:end],
+			Err:     err,
+		})
+	}
+
+	return report, nil
+}
 
-		resp, err := cs.rest.Post(ctx, endpoint, strings.NewReader(string(payload)))
+// postCellUpdates sends a list of cell updates as a single tm1.Update request.
+func (cs *CellService) postCellUpdates(ctx context.Context, endpoint string, cellUpdates []map[string]interface{}) error {
+	payload, err := json.Marshal(cellUpdates)
+	if err != nil {
+		return fmt.Errorf("marshal cell updates: %w", err)
+	}
+
+	resp, err := cs.rest.Post(ctx, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("post cell updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// composeTupleBindings builds the OData element bindings for one cell.
+// See resolveCoordinate for the accepted dimension and element formats.
+func composeTupleBindings(elements []string, dimensions []string) []string {
+	tupleBindings, _ := composeResolvedTupleBindings(elements, dimensions, nil)
+	return tupleBindings
+}
+
+// composeResolvedTupleBindings builds the OData element bindings for one cell, replacing element
+// names and aliases of hierarchies with a resolver by their principal names.
+func composeResolvedTupleBindings(elements []string, dimensions []string, resolvers elementResolvers) ([]string, error) {
+	tupleBindings := make([]string, 0, len(elements))
+	for i, element := range elements {
+		dim, hier, elem := resolveCoordinate(dimensions[i], element)
+		elem, err := resolvers.resolve(dim, hier, elem)
 		if err != nil {
-			return fmt.Errorf("post cell update: %w", err)
+			return nil, err
 		}
-		resp.Body.Close()
+		tupleBindings = append(tupleBindings, fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
+			escapeODataKey(dim), escapeODataKey(hier), escapeODataKey(elem)))
 	}
+	return tupleBindings, nil
+}
 
-	return nil
+// resolveCoordinate resolves the dimension, hierarchy and element addressed by one tuple position.
+// dimension may be "dimension", "dimension:hierarchy" or "[dimension].[hierarchy]".
+// element may be a plain element name, "dimension:hierarchy:element" or a unique name
+// "[dimension].[hierarchy].[element]" / "[dimension].[element]"; when element is qualified,
+// its dimension and hierarchy take precedence. Without a hierarchy, the default hierarchy
+// (named like the dimension) is used.
+func resolveCoordinate(dimension, element string) (string, string, string) {
+	dim, hier := ExtractDimensionHierarchyFromString(dimension)
+	if hier == "" {
+		hier = dim
+	}
+
+	if parts := splitUniqueName(element); len(parts) == 3 {
+		return parts[0], parts[1], parts[2]
+	} else if len(parts) == 2 {
+		return parts[0], parts[0], parts[1]
+	}
+
+	if parts := strings.SplitN(element, ":", 3); len(parts) == 3 && caseAndSpaceInsensitiveEquals(parts[0], dim) {
+		return parts[0], parts[1], parts[2]
+	}
+
+	return dim, hier, element
 }
 
 // CreateCellset creates a cellset from an MDX query
@@ -549,7 +862,7 @@ func (cs *CellService) CreateCellsetFromView(ctx context.Context, cubeName, view
 	}
 
 	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')/tm1.Execute",
-		url.PathEscape(cubeName), viewType, url.PathEscape(viewName))
+		escapeODataKey(cubeName), viewType, escapeODataKey(viewName))
 
 	if sandboxName != "" {
 		endpoint = addSandboxParam(endpoint, sandboxName)
@@ -693,7 +1006,7 @@ func (cs *CellService) DeleteCellset(ctx context.Context, cellsetID string, sand
 
 // getDimensionNamesForCube retrieves dimension names for a cube (excluding sandbox dimension)
 func (cs *CellService) getDimensionNamesForCube(ctx context.Context, cubeName string) ([]string, error) {
-	endpoint := fmt.Sprintf("/Cubes('%s')/Dimensions?$select=Name", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/Dimensions?$select=Name", escapeODataKey(cubeName))
 
 	resp, err := cs.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -932,16 +1245,8 @@ func (cs *CellService) composeODataTupleFromElements(cubeName string, elements [
 		return nil, fmt.Errorf("elements count (%d) must match dimensions count (%d)", len(elements), len(dimensions))
 	}
 
-	tupleBindings := make([]string, 0, len(elements))
-	for i, elem := range elements {
-		dim := dimensions[i]
-		hier := dim // Default hierarchy has same name as dimension
-		tupleBindings = append(tupleBindings, fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-			url.PathEscape(dim), url.PathEscape(hier), url.PathEscape(elem)))
-	}
-
 	return map[string]interface{}{
-		"Tuple@odata.bind": tupleBindings,
+		"Tuple@odata.bind": composeTupleBindings(elements, dimensions),
 	}, nil
 }
 
@@ -978,7 +1283,7 @@ func (cs *CellService) TraceCellCalculation(ctx context.Context, cubeName string
 	}
 
 	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.TraceCellCalculation?$select=Type,Value,Statements,%s&$expand=Tuple($select=Name,UniqueName,Type),%s",
-		url.PathEscape(cubeName), selectQuery, expandQuery)
+		escapeODataKey(cubeName), selectQuery, expandQuery)
 
 	if sandboxName != "" {
 		endpoint = addSandboxParam(endpoint, sandboxName)
@@ -1012,7 +1317,7 @@ func (cs *CellService) TraceCellCalculation(ctx context.Context, cubeName string
 // Returns the feeder statements and the collection of fed cells.
 func (cs *CellService) TraceCellFeeders(ctx context.Context, cubeName string, elements []string, dimensions []string, sandboxName string) (*FeederTrace, error) {
 	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.TraceFeeders?$select=Statements,FedCells&$expand=FedCells/Tuple($select=Name,UniqueName,Type),FedCells/Cube($select=Name)",
-		url.PathEscape(cubeName))
+		escapeODataKey(cubeName))
 
 	if sandboxName != "" {
 		endpoint = addSandboxParam(endpoint, sandboxName)
@@ -1046,7 +1351,7 @@ func (cs *CellService) TraceCellFeeders(ctx context.Context, cubeName string, el
 // Returns a list of fed cell descriptors indicating which components are not properly fed.
 func (cs *CellService) CheckCellFeeders(ctx context.Context, cubeName string, elements []string, dimensions []string, sandboxName string) ([]FedCellDescriptor, error) {
 	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.CheckFeeders?$select=Fed&$expand=Tuple($select=Name,UniqueName,Type),Cube($select=Name)",
-		url.PathEscape(cubeName))
+		escapeODataKey(cubeName))
 
 	if sandboxName != "" {
 		endpoint = addSandboxParam(endpoint, sandboxName)
@@ -1082,7 +1387,7 @@ func (cs *CellService) CheckCellFeeders(ctx context.Context, cubeName string, el
 // If rules is empty, the cube's existing rules are checked.
 // Returns a list of RuleSyntaxError; an empty list means the rules are valid.
 func (cs *CellService) CheckRules(ctx context.Context, cubeName string, rules string) ([]RuleSyntaxError, error) {
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.CheckRules", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.CheckRules", escapeODataKey(cubeName))
 
 	body := map[string]interface{}{}
 	if rules != "" {
@@ -1136,19 +1441,9 @@ func (cs *CellService) postAgainstCellset(ctx context.Context, cellsetID string,
 // parseDimensionHierarchyElementFromUniqueName parses a unique element name like "[dimension].[element]"
 // or "[dimension].[hierarchy].[element]" and returns (dimension, hierarchy, element).
 func parseDimensionHierarchyElementFromUniqueName(uniqueName string) (string, string, string) {
-	// Remove leading/trailing whitespace
 	uniqueName = strings.TrimSpace(uniqueName)
 
-	// Extract parts between brackets
-	parts := make([]string, 0, 3)
-	for _, segment := range strings.Split(uniqueName, "]") {
-		segment = strings.TrimLeft(segment, ".[")
-		segment = strings.TrimSpace(segment)
-		if segment != "" {
-			parts = append(parts, segment)
-		}
-	}
-
+	parts := splitUniqueName(uniqueName)
 	switch len(parts) {
 	case 3:
 		return parts[0], parts[1], parts[2]
@@ -1182,7 +1477,7 @@ func (cs *CellService) RelativeProportionalSpread(ctx context.Context, value flo
 	for _, refUEN := range referenceUniqueElementNames {
 		dim, hier, elem := parseDimensionHierarchyElementFromUniqueName(refUEN)
 		refCellBindings = append(refCellBindings, fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-			url.PathEscape(dim), url.PathEscape(hier), url.PathEscape(elem)))
+			escapeODataKey(dim), escapeODataKey(hier), escapeODataKey(elem)))
 	}
 
 	if referenceCube == "" {
@@ -1193,7 +1488,7 @@ func (cs *CellService) RelativeProportionalSpread(ctx context.Context, value flo
 		"BeginOrdinal":             0,
 		"Value":                    fmt.Sprintf("RP%g", value),
 		"ReferenceCell@odata.bind": refCellBindings,
-		"ReferenceCube@odata.bind": fmt.Sprintf("Cubes('%s')", url.PathEscape(referenceCube)),
+		"ReferenceCube@odata.bind": fmt.Sprintf("Cubes('%s')", escapeODataKey(referenceCube)),
 	}
 
 	err = cs.postAgainstCellset(ctx, cellsetID, payload, sandboxName)
@@ -1223,7 +1518,7 @@ func (cs *CellService) ClearSpread(ctx context.Context, cubeName string, uniqueE
 	for _, uen := range uniqueElementNames {
 		dim, hier, elem := parseDimensionHierarchyElementFromUniqueName(uen)
 		refCellBindings = append(refCellBindings, fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-			url.PathEscape(dim), url.PathEscape(hier), url.PathEscape(elem)))
+			escapeODataKey(dim), escapeODataKey(hier), escapeODataKey(elem)))
 	}
 
 	payload := map[string]interface{}{
@@ -1260,7 +1555,7 @@ func (cs *CellService) EqualSpread(ctx context.Context, value float64, cubeName
 	for _, uen := range uniqueElementNames {
 		dim, hier, elem := parseDimensionHierarchyElementFromUniqueName(uen)
 		refCellBindings = append(refCellBindings, fmt.Sprintf("Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-			url.PathEscape(dim), url.PathEscape(hier), url.PathEscape(elem)))
+			escapeODataKey(dim), escapeODataKey(hier), escapeODataKey(elem)))
 	}
 
 	payload := map[string]interface{}{
@@ -1295,7 +1590,7 @@ func (cs *CellService) ClearWithMDX(ctx context.Context, cubeName string, mdx st
 		return fmt.Errorf("marshal view body: %w", err)
 	}
 
-	viewEndpoint := fmt.Sprintf("/Cubes('%s')/Views", url.PathEscape(cubeName))
+	viewEndpoint := fmt.Sprintf("/Cubes('%s')/Views", escapeODataKey(cubeName))
 	resp, err := cs.rest.Post(ctx, viewEndpoint, strings.NewReader(string(viewPayload)))
 	if err != nil {
 		return fmt.Errorf("create view: %w", err)
@@ -1304,7 +1599,7 @@ func (cs *CellService) ClearWithMDX(ctx context.Context, cubeName string, mdx st
 
 	// Ensure view cleanup
 	defer func() {
-		deleteEndpoint := fmt.Sprintf("/Cubes('%s')/Views('%s')", url.PathEscape(cubeName), url.PathEscape(viewName))
+		deleteEndpoint := fmt.Sprintf("/Cubes('%s')/Views('%s')", escapeODataKey(cubeName), escapeODataKey(viewName))
 		if delResp, delErr := cs.rest.Delete(ctx, deleteEndpoint); delErr == nil {
 			delResp.Body.Close()
 		}
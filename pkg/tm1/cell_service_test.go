@@ -1,6 +1,12 @@
 package tm1
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
 	"testing"
 )
 
@@ -114,3 +120,182 @@ func TestCellService_ordinalToCoordinates(t *testing.T) {
 		})
 	}
 }
+
+func TestCellServiceWriteValuesByCoordsChunked(t *testing.T) {
+	var mu sync.Mutex
+	chunkSizes := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/Cubes('Sales')/tm1.Update" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var updates []struct {
+			Cells []struct {
+				Tuple []string `json:"Tuple@odata.bind"`
+			} `json:"Cells"`
+			Value interface{} `json:"Value"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		first := updates[0].Cells[0].Tuple[0]
+		mu.Lock()
+		chunkSizes[first] = len(updates)
+		mu.Unlock()
+
+		// Reject the chunk that starts with e3
+		if first == "Dimensions('Region')/Hierarchies('Region')/Elements('e3')" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rest, _ := NewRestService(cfg)
+	mockBaseURL, _ := url.Parse(server.URL)
+	rest.SetBaseURL(mockBaseURL.String())
+
+	service := NewCellService(rest)
+	coords := [][]string{{"e1", "Units"}, {"e2", "Units"}, {"e3", "Units"}, {"e4", "Units"}, {"e5", "Units"}}
+	values := []interface{}{1, 2, 3, 4, 5}
+
+	report, err := service.WriteValuesByCoordsChunked(context.Background(), "Sales", coords, values, []string{"Region", "Measure"}, "", WriteParams{ChunkSize: 2, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("WriteValuesByCoordsChunked() error = %v", err)
+	}
+
+	if report.Chunks != 3 || len(chunkSizes) != 3 {
+		t.Fatalf("chunks = %d, requests = %d, want 3/3", report.Chunks, len(chunkSizes))
+	}
+	if report.CellsWritten != 3 {
+		t.Fatalf("CellsWritten = %d, want 3", report.CellsWritten)
+	}
+	if len(report.Failed) != 1 {
+		t.Fatalf("Failed = %d, want 1", len(report.Failed))
+	}
+
+	failed := report.Failed[0]
+	if failed.Index != 1 || failed.Offset != 2 || len(failed.Coords) != 2 || failed.Values[0] != 3 {
+		t.Fatalf("unexpected failed chunk: %+v", failed)
+	}
+	if report.Err() == nil {
+		t.Fatal("Err() = nil, want error for failed chunk")
+	}
+
+	err = service.WriteValuesByCoords(context.Background(), "Sales", coords[:2], values[:2], []string{"Region", "Measure"}, "")
+	if err != nil {
+		t.Fatalf("WriteValuesByCoords() error = %v", err)
+	}
+}
+
+func TestResolveCoordinate(t *testing.T) {
+	tests := []struct {
+		name      string
+		dimension string
+		element   string
+		want      [3]string
+	}{
+		{name: "plain element", dimension: "Region", element: "Europe", want: [3]string{"Region", "Region", "Europe"}},
+		{name: "dimension with hierarchy", dimension: "Region:By Country", element: "Europe", want: [3]string{"Region", "By Country", "Europe"}},
+		{name: "bracketed dimension with hierarchy", dimension: "[Region].[By Country]", element: "Europe", want: [3]string{"Region", "By Country", "Europe"}},
+		{name: "colon qualified element", dimension: "Region", element: "Region:By Country:Europe", want: [3]string{"Region", "By Country", "Europe"}},
+		{name: "colon in plain element name", dimension: "Time", element: "12:00:00", want: [3]string{"Time", "Time", "12:00:00"}},
+		{name: "unique name", dimension: "Region", element: "[Region].[By Country].[Europe]", want: [3]string{"Region", "By Country", "Europe"}},
+		{name: "unique name without hierarchy", dimension: "Region", element: "[Region].[Europe]", want: [3]string{"Region", "Region", "Europe"}},
+		{name: "unique name with escaped bracket", dimension: "Region", element: "[Region].[Region].[a]]b]", want: [3]string{"Region", "Region", "a]b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dim, hier, elem := resolveCoordinate(tt.dimension, tt.element)
+			if got := [3]string{dim, hier, elem}; got != tt.want {
+				t.Errorf("resolveCoordinate(%q, %q) = %v, want %v", tt.dimension, tt.element, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellServiceGetValueAlternateHierarchy(t *testing.T) {
+	var mdx string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/ExecuteMDX":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mdx = body["MDX"]
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"ID":"abc"}`))
+		case r.Method == "GET" && r.URL.Path == "/Cellsets('abc')":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"ID":"abc","Axes":[],"Cells":[{"Ordinal":0,"Value":42}]}`))
+		case r.Method == "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rest, _ := NewRestService(cfg)
+	mockBaseURL, _ := url.Parse(server.URL)
+	rest.SetBaseURL(mockBaseURL.String())
+
+	service := NewCellService(rest)
+	value, err := service.GetValue(context.Background(), "Sales", []string{"Region:By Country:Europe", "Units"}, []string{"Region", "Measure"}, "")
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	if value != float64(42) {
+		t.Fatalf("GetValue() = %v, want 42", value)
+	}
+
+	want := "SELECT [Region].[By Country].[Europe] ON ROWS, [Measure].[Measure].[Units] ON COLUMNS FROM [Sales]"
+	if mdx != want {
+		t.Fatalf("MDX = %q, want %q", mdx, want)
+	}
+}
+
+func TestCellServiceExecuteDrillthrough(t *testing.T) {
+	deleted := ""
+	cells := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/Cellsets('src')/Cells(3)/DrillthroughScripts":
+			w.Write([]byte(`{"value":[{"Name":"Detail"},{"Name":"O'Brien"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/Cellsets('src')/Cells(3)/DrillthroughScripts('O''Brien')/tm1.Execute":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"drill"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/Cellsets('drill')":
+			w.Write([]byte(`{"ID":"drill","Cells":[{"Ordinal":0,"Value":"INV-1"}]}`))
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	scripts, err := cells.GetDrillthroughScripts(context.Background(), "src", 3, "")
+	if err != nil {
+		t.Fatalf("GetDrillthroughScripts() failed: %v", err)
+	}
+	if len(scripts) != 2 || scripts[1] != "O'Brien" {
+		t.Errorf("scripts = %v", scripts)
+	}
+
+	cellset, err := cells.ExecuteDrillthrough(context.Background(), "src", 3, scripts[1], nil, "")
+	if err != nil {
+		t.Fatalf("ExecuteDrillthrough() failed: %v", err)
+	}
+	if len(cellset.Cells) != 1 || cellset.Cells[0].Value != "INV-1" {
+		t.Errorf("cells = %+v", cellset.Cells)
+	}
+	if deleted != "/Cellsets('drill')" {
+		t.Errorf("deleted = %q, want drillthrough cellset", deleted)
+	}
+}
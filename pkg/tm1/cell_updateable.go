@@ -0,0 +1,89 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RejectionReason tells why a cell was left out of a write by WriteParams.CheckUpdateable.
+type RejectionReason string
+
+const (
+	RejectedConsolidated   RejectionReason = "consolidated"
+	RejectedRuleCalculated RejectionReason = "rule-calculated"
+	RejectedSecurity       RejectionReason = "security"
+	RejectedLocked         RejectionReason = "locked" // Any other reason the server reports, such as locks or data reservations
+)
+
+// RejectedCell is a cell the server reported as not updateable, and which was therefore not written.
+type RejectedCell struct {
+	Index  int    // Position in the original coords and values
+	Key    string // Coordinate key, set by WriteValuesWithParams
+	Coords []string
+	Value  interface{}
+	Reason RejectionReason
+}
+
+// RejectedCellsError lists the cells left out of a write by an updateability check.
+type RejectedCellsError struct {
+	Cells []RejectedCell
+}
+
+// Error implements error.
+func (e *RejectedCellsError) Error() string {
+	first := e.Cells[0]
+	if first.Key != "" {
+		return fmt.Sprintf("%d cells not updateable, first %q: %s", len(e.Cells), first.Key, first.Reason)
+	}
+	return fmt.Sprintf("%d cells not updateable, first at index %d (%s): %s",
+		len(e.Cells), first.Index, strings.Join(first.Coords, ","), first.Reason)
+}
+
+// checkUpdateable reads the Updateable property of the cells, one query per write chunk, and returns
+// the rejection reason of each cell, or "" for cells that can be written.
+func (cs *CellService) checkUpdateable(ctx context.Context, cubeName string, coords [][]string, dimensions []string, sandboxName string, chunkSize int) ([]RejectionReason, error) {
+	reasons := make([]RejectionReason, len(coords))
+	for start := 0; start < len(coords); start += chunkSize {
+		end := min(start+chunkSize, len(coords))
+		tuples := make([][]MDXMember, 0, end-start)
+		for _, elements := range coords[start:end] {
+			tuple := make([]MDXMember, len(elements))
+			for i, element := range elements {
+				dim, hier, elem := resolveCoordinate(dimensions[i], element)
+				tuple[i] = NewMDXMember(dim, hier, elem)
+			}
+			tuples = append(tuples, tuple)
+		}
+
+		mdx, err := NewMDXQuery(cubeName).Columns(TuplesSet(tuples...)).Build()
+		if err != nil {
+			return nil, err
+		}
+		cellset, err := cs.ExecuteMDX(ctx, mdx, []string{"Ordinal", "Updateable", "Consolidated", "RuleDerived"}, sandboxName)
+		if err != nil {
+			return nil, fmt.Errorf("check updateable: %w", err)
+		}
+		for _, cell := range cellset.Cells {
+			if i := start + cell.Ordinal; i < end {
+				reasons[i] = rejectionReason(cell)
+			}
+		}
+	}
+	return reasons, nil
+}
+
+// rejectionReason returns why a cell is not updateable, or "" if it is.
+func rejectionReason(cell Cell) RejectionReason {
+	switch {
+	case CellIsUpdateable(cell):
+		return ""
+	case cell.RuleDerived || ExtractCellUpdateableProperty(cell.Updateable, RULE_IS_APPLIED):
+		return RejectedRuleCalculated
+	case cell.Consolidated:
+		return RejectedConsolidated
+	case ExtractCellUpdateableProperty(cell.Updateable, SECURITY_RESTRICTED):
+		return RejectedSecurity
+	}
+	return RejectedLocked
+}
@@ -0,0 +1,189 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+func TestCellServiceWriteCheckUpdateable(t *testing.T) {
+	var updates []map[string]interface{}
+	cells := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"upd"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/Cellsets('upd')":
+			w.Write([]byte(`{"Cells":[
+				{"Ordinal":0,"Updateable":0},
+				{"Ordinal":1,"Updateable":268435456,"Consolidated":true},
+				{"Ordinal":2,"Updateable":268435460},
+				{"Ordinal":3,"Updateable":268435457},
+				{"Ordinal":4,"Updateable":268435456},
+				{"Ordinal":5,"Updateable":256}]}`))
+		case r.URL.Path == "/Cubes('Sales')/tm1.Update":
+			json.NewDecoder(r.Body).Decode(&updates)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	coords := [][]string{{"Jan"}, {"Q1"}, {"Feb"}, {"Mar"}, {"Apr"}, {"May"}}
+	values := []interface{}{1, 2, 3, 4, 5, 6}
+	report, err := cells.WriteValuesByCoordsChunked(context.Background(), "Sales", coords, values, []string{"Period"}, "", WriteParams{CheckUpdateable: true})
+	if err != nil {
+		t.Fatalf("WriteValuesByCoordsChunked() failed: %v", err)
+	}
+
+	wantReasons := []RejectionReason{RejectedConsolidated, RejectedRuleCalculated, RejectedSecurity, RejectedLocked}
+	if len(report.Rejected) != len(wantReasons) {
+		t.Fatalf("rejected = %+v", report.Rejected)
+	}
+	for i, rejected := range report.Rejected {
+		if rejected.Index != i+1 || rejected.Reason != wantReasons[i] || rejected.Value != values[i+1] {
+			t.Errorf("rejected[%d] = %+v", i, rejected)
+		}
+	}
+	if report.CellsWritten != 2 || len(updates) != 2 || updates[1]["Value"] != 6.0 {
+		t.Errorf("report = %+v, updates = %v", report, updates)
+	}
+
+	message := (&RejectedCellsError{Cells: report.Rejected}).Error()
+	if want := "4 cells not updateable, first at index 1 (Q1): consolidated"; message != want {
+		t.Errorf("Error() = %q, want %q", message, want)
+	}
+}
+
+func TestCellServiceWriteValuesWithParamsReportsKeys(t *testing.T) {
+	member := regexp.MustCompile(`\[Period\]\.\[Period\]\.\[(\w+)\]`)
+	var mu sync.Mutex
+	cellsets := map[string][]string{}
+	cells := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			id := fmt.Sprintf("upd%d", len(cellsets))
+			for _, match := range member.FindAllStringSubmatch(string(body), -1) {
+				cellsets[id] = append(cellsets[id], match[1])
+			}
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"ID":"%s"}`, id)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/Cellsets('upd"):
+			mu.Lock()
+			elements := cellsets[strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/Cellsets('"), "')")]
+			mu.Unlock()
+			var result []string
+			for ordinal, element := range elements {
+				if element == "Q1" {
+					result = append(result, fmt.Sprintf(`{"Ordinal":%d,"Updateable":268435456,"Consolidated":true}`, ordinal))
+				} else {
+					result = append(result, fmt.Sprintf(`{"Ordinal":%d,"Updateable":0}`, ordinal))
+				}
+			}
+			fmt.Fprintf(w, `{"Cells":[%s]}`, strings.Join(result, ","))
+		case r.URL.Path == "/Cubes('Sales')/tm1.Update":
+			body, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(body), "Elements('Mar')") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	values := map[string]interface{}{"Jan": 1, "Q1": 2, "Feb": 3, "Mar": 4}
+	report, err := cells.WriteValuesWithParams(context.Background(), "Sales", values, []string{"Period"}, "", WriteParams{ChunkSize: 2, CheckUpdateable: true})
+	if err != nil {
+		t.Fatalf("WriteValuesWithParams() failed: %v", err)
+	}
+
+	if len(report.Rejected) != 1 || report.Rejected[0].Key != "Q1" || report.Rejected[0].Value != 2 {
+		t.Fatalf("rejected = %+v", report.Rejected)
+	}
+	if message := (&RejectedCellsError{Cells: report.Rejected}).Error(); message != `1 cells not updateable, first "Q1": consolidated` {
+		t.Errorf("RejectedCellsError.Error() = %q", message)
+	}
+	if len(report.Failed) != 1 {
+		t.Fatalf("failed = %+v", report.Failed)
+	}
+	failed := report.Failed[0]
+	if !SliceContains(failed.Keys, "Mar") || len(failed.Keys) != len(failed.Coords) {
+		t.Fatalf("failed chunk = %+v", failed)
+	}
+	for i, key := range failed.Keys {
+		if failed.Coords[i][0] != key || failed.Values[i] != values[key] {
+			t.Errorf("failed cell %d: key %q, coords %v, value %v", i, key, failed.Coords[i], failed.Values[i])
+		}
+	}
+	if message := failed.Error(); !strings.HasPrefix(message, fmt.Sprintf("chunk %d (%d cells from %q): ", failed.Index, len(failed.Keys), failed.Keys[0])) {
+		t.Errorf("Error() = %q", message)
+	}
+}
+
+func TestCellServiceWriteValuesReturnsFirstChunkError(t *testing.T) {
+	cells := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	err := cells.WriteValues(context.Background(), "Sales", map[string]interface{}{"Jan": 1}, []string{"Period"}, "")
+	var chunkErr WriteChunkError
+	var httpErr *HTTPError
+	if !errors.As(err, &chunkErr) || !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("WriteValues() error = %v, want the chunk's HTTP error", err)
+	}
+	if strings.Contains(err.Error(), "chunks failed") {
+		t.Errorf("WriteValues() error = %q, want the first chunk error", err)
+	}
+}
+
+func TestCellServiceWriteDataFrameReturnsRejectedAndFailedCells(t *testing.T) {
+	cells := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/ExecuteMDX":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ID":"upd"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/Cellsets('upd')":
+			w.Write([]byte(`{"Cells":[{"Ordinal":0,"Updateable":0},{"Ordinal":1,"Updateable":268435456,"Consolidated":true}]}`))
+		case r.URL.Path == "/Cubes('Sales')/tm1.Update":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	df := dataframe.New(
+		series.New([]string{"Jan", "Q1"}, series.String, "Period"),
+		series.New([]float64{1, 2}, series.Float, "Value"),
+	)
+	err := cells.WriteDataFrame(context.Background(), "Sales", df, nil, "", "", WithUpdateableCheck())
+
+	var rejected *RejectedCellsError
+	if !errors.As(err, &rejected) || len(rejected.Cells) != 1 || rejected.Cells[0].Coords[0] != "Q1" {
+		t.Errorf("WriteDataFrame() error = %v, want the rejected cell", err)
+	}
+	var chunkErr WriteChunkError
+	if !errors.As(err, &chunkErr) || chunkErr.Coords[0][0] != "Jan" {
+		t.Errorf("WriteDataFrame() error = %v, want the failed chunk", err)
+	}
+}
@@ -0,0 +1,169 @@
+package tm1
+
+import "strings"
+
+// CellsetRow is a row of a cellset: the tuple on the rows axis and the cells for each column.
+type CellsetRow struct {
+	Index int     // Tuple index on the rows axis
+	Tuple Tuple   // Rows axis tuple, empty when the cellset has no rows axis
+	Cells []*Cell // Cells in column order; nil entries are cells missing from the payload
+}
+
+// ColumnCount returns the number of tuples on the columns axis (axis 0).
+func (c *Cellset) ColumnCount() int {
+	return c.axisCardinality(0)
+}
+
+// RowCount returns the number of tuples on the rows axis (axis 1), or 1 when the cellset
+// only has a columns axis.
+func (c *Cellset) RowCount() int {
+	if len(c.Axes) < 2 {
+		if len(c.Axes) == 0 {
+			return 0
+		}
+		return 1
+	}
+	return c.axisCardinality(1)
+}
+
+// Cell returns the cell at the given row and column tuple indexes. Axes beyond rows and
+// columns (such as the slicer) are expected to hold a single tuple.
+func (c *Cellset) Cell(rowIdx, colIdx int) (*Cell, bool) {
+	if rowIdx < 0 || colIdx < 0 || rowIdx >= c.RowCount() || colIdx >= c.ColumnCount() {
+		return nil, false
+	}
+	return c.cellByOrdinal(rowIdx*c.ColumnCount() + colIdx)
+}
+
+// Lookup returns the cell addressed by element names keyed by dimension. Keys may be a
+// dimension name, "dimension:hierarchy" or "[dimension].[hierarchy]"; names are compared
+// ignoring case and spaces. Every axis with more than one tuple must be fully addressed.
+func (c *Cellset) Lookup(coordinates map[string]string) (*Cell, bool) {
+	type target struct {
+		dimension, hierarchy, element string
+	}
+	targets := make([]target, 0, len(coordinates))
+	for key, element := range coordinates {
+		dimension, hierarchy := ExtractDimensionHierarchyFromString(key)
+		if !strings.Contains(key, ":") && !strings.HasPrefix(strings.TrimSpace(key), "[") {
+			hierarchy = ""
+		}
+		targets = append(targets, target{dimension: dimension, hierarchy: hierarchy, element: element})
+	}
+
+	ordinal, stride := 0, 1
+	for _, axis := range c.Axes {
+		cardinality := len(axis.Tuples)
+		tupleIdx := -1
+		for i, tuple := range axis.Tuples {
+			matched, addressed := 0, 0
+			for _, member := range tuple.Members {
+				dimension, hierarchy, element := memberCoordinate(member)
+				for _, t := range targets {
+					if !caseAndSpaceInsensitiveEquals(t.dimension, dimension) {
+						continue
+					}
+					if t.hierarchy != "" && !caseAndSpaceInsensitiveEquals(t.hierarchy, hierarchy) {
+						continue
+					}
+					addressed++
+					if caseAndSpaceInsensitiveEquals(t.element, element) {
+						matched++
+					}
+					break
+				}
+			}
+			if addressed == 0 && cardinality == 1 {
+				tupleIdx = 0
+				break
+			}
+			if addressed == len(tuple.Members) && matched == addressed {
+				tupleIdx = i
+				break
+			}
+		}
+		if tupleIdx < 0 {
+			return nil, false
+		}
+		ordinal += tupleIdx * stride
+		stride *= cardinality
+	}
+
+	return c.cellByOrdinal(ordinal)
+}
+
+// Rows returns the cellset rows in rows axis order.
+func (c *Cellset) Rows() []CellsetRow {
+	rowCount, colCount := c.RowCount(), c.ColumnCount()
+	rows := make([]CellsetRow, rowCount)
+	for r := 0; r < rowCount; r++ {
+		row := CellsetRow{Index: r, Cells: make([]*Cell, colCount)}
+		if len(c.Axes) > 1 && r < len(c.Axes[1].Tuples) {
+			row.Tuple = c.Axes[1].Tuples[r]
+		}
+		for col := 0; col < colCount; col++ {
+			row.Cells[col], _ = c.cellByOrdinal(r*colCount + col)
+		}
+		rows[r] = row
+	}
+	return rows
+}
+
+// ToMatrix returns the cell values as a rows by columns matrix. Missing cells are nil.
+func (c *Cellset) ToMatrix() [][]interface{} {
+	rows := c.Rows()
+	matrix := make([][]interface{}, len(rows))
+	for r, row := range rows {
+		matrix[r] = make([]interface{}, len(row.Cells))
+		for col, cell := range row.Cells {
+			if cell != nil {
+				matrix[r][col] = cell.Value
+			}
+		}
+	}
+	return matrix
+}
+
+func (c *Cellset) axisCardinality(axisIdx int) int {
+	if axisIdx >= len(c.Axes) {
+		return 0
+	}
+	axis := c.Axes[axisIdx]
+	if len(axis.Tuples) > 0 {
+		return len(axis.Tuples)
+	}
+	return axis.Cardinality
+}
+
+// cellByOrdinal finds a cell by ordinal. Cells are positional when the payload holds every
+// cell of the cellset, otherwise they are matched on their Ordinal property.
+func (c *Cellset) cellByOrdinal(ordinal int) (*Cell, bool) {
+	total := 1
+	for i := range c.Axes {
+		total *= c.axisCardinality(i)
+	}
+	if len(c.Cells) == total {
+		if ordinal < 0 || ordinal >= total {
+			return nil, false
+		}
+		return &c.Cells[ordinal], true
+	}
+	for i := range c.Cells {
+		if c.Cells[i].Ordinal == ordinal {
+			return &c.Cells[i], true
+		}
+	}
+	return nil, false
+}
+
+// memberCoordinate returns the dimension, hierarchy and element name of an axis member.
+func memberCoordinate(member Member) (string, string, string) {
+	parts, _ := parseUniqueNameSegments(member.UniqueName)
+	switch {
+	case len(parts) >= 3:
+		return parts[0], parts[1], member.Name
+	case len(parts) == 2:
+		return parts[0], parts[0], member.Name
+	}
+	return member.DimensionName, member.HierarchyName, member.Name
+}
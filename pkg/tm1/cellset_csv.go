@@ -0,0 +1,324 @@
+package tm1
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// CSVParams controls the CSV export of a cellset.
+type CSVParams struct {
+	Delimiter        rune   // Field delimiter (default: ',')
+	SkipZeros        bool   // Omit cells whose value is null, zero or an empty string
+	SkipConsolidated bool   // Omit consolidated cells
+	SkipRuleDerived  bool   // Omit rule-derived cells
+	SandboxName      string // Sandbox to read from
+}
+
+// ExecuteMDXCSV executes an MDX query and returns the result as CSV text.
+// See StreamCellsetRows for the layout of the output.
+func (cs *CellService) ExecuteMDXCSV(ctx context.Context, mdx string, params CSVParams) (string, error) {
+	cellsetID, err := cs.CreateCellset(ctx, mdx, params.SandboxName)
+	if err != nil {
+		return "", fmt.Errorf("create cellset: %w", err)
+	}
+	return cs.ExtractCellsetCSV(ctx, cellsetID, true, params)
+}
+
+// ExecuteViewCSV executes a cube view and returns the result as CSV text.
+// See StreamCellsetRows for the layout of the output.
+func (cs *CellService) ExecuteViewCSV(ctx context.Context, cubeName, viewName string, private bool, params CSVParams) (string, error) {
+	cellsetID, err := cs.CreateCellsetFromView(ctx, cubeName, viewName, private, params.SandboxName)
+	if err != nil {
+		return "", fmt.Errorf("create cellset from view: %w", err)
+	}
+	return cs.ExtractCellsetCSV(ctx, cellsetID, true, params)
+}
+
+// ExecuteMDXRows executes an MDX query and returns the result as parsed CSV rows.
+func (cs *CellService) ExecuteMDXRows(ctx context.Context, mdx string, params CSVParams) ([][]string, error) {
+	cellsetID, err := cs.CreateCellset(ctx, mdx, params.SandboxName)
+	if err != nil {
+		return nil, fmt.Errorf("create cellset: %w", err)
+	}
+	return cs.ExtractCellsetRows(ctx, cellsetID, true, params)
+}
+
+// ExecuteViewRows executes a cube view and returns the result as parsed CSV rows.
+func (cs *CellService) ExecuteViewRows(ctx context.Context, cubeName, viewName string, private bool, params CSVParams) ([][]string, error) {
+	cellsetID, err := cs.CreateCellsetFromView(ctx, cubeName, viewName, private, params.SandboxName)
+	if err != nil {
+		return nil, fmt.Errorf("create cellset from view: %w", err)
+	}
+	return cs.ExtractCellsetRows(ctx, cellsetID, true, params)
+}
+
+// ExecuteDrillthroughRows executes a drillthrough script on a cell and returns the result as parsed CSV rows.
+// See ExecuteDrillthrough.
+func (cs *CellService) ExecuteDrillthroughRows(ctx context.Context, cellsetID string, ordinal int, scriptName string, params CSVParams) ([][]string, error) {
+	drillCellsetID, err := cs.CreateDrillthroughCellset(ctx, cellsetID, ordinal, scriptName, params.SandboxName)
+	if err != nil {
+		return nil, err
+	}
+	return cs.ExtractCellsetRows(ctx, drillCellsetID, true, params)
+}
+
+// ExtractCellsetCSV extracts a cellset as CSV text, re-encoding the server export as it is read.
+func (cs *CellService) ExtractCellsetCSV(ctx context.Context, cellsetID string, deleteCellset bool, params CSVParams) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if params.Delimiter != 0 {
+		writer.Comma = params.Delimiter
+	}
+	if err := cs.StreamCellsetRows(ctx, cellsetID, deleteCellset, params, writer.Write); err != nil {
+		return "", err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("write csv: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// ExtractCellsetRows extracts a cellset as rows of strings. See StreamCellsetRows.
+func (cs *CellService) ExtractCellsetRows(ctx context.Context, cellsetID string, deleteCellset bool, params CSVParams) ([][]string, error) {
+	var rows [][]string
+	err := cs.StreamCellsetRows(ctx, cellsetID, deleteCellset, params, func(row []string) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// StreamCellsetRows reads a cellset through the server CSV export (/Cellsets('id')/Content) and
+// calls fn with each row as it is parsed, so the cellset is never held in memory as a whole.
+// An error returned by fn stops the export.
+//
+// The first row is a header with one column per hierarchy followed by "Value". Hierarchies are
+// ordered rows axis first, then columns axis, then any further axes such as the slicer, which is
+// the order of the member names on each line of the export. Columns of alternate hierarchies are
+// named "dimension:hierarchy".
+//
+// The export carries member names and values only. With SkipConsolidated or SkipRuleDerived the
+// rows are built from the JSON cellset instead, which needs those cell properties.
+// With deleteCellset, a failure to delete the cellset is joined to the returned error.
+func (cs *CellService) StreamCellsetRows(ctx context.Context, cellsetID string, deleteCellset bool, params CSVParams, fn func(row []string) error) (err error) {
+	if deleteCellset {
+		defer func() {
+			if deleteErr := cs.DeleteCellset(ctx, cellsetID, params.SandboxName); deleteErr != nil {
+				err = errors.Join(err, fmt.Errorf("delete cellset: %w", deleteErr))
+			}
+		}()
+	}
+	if params.SkipConsolidated || params.SkipRuleDerived {
+		return cs.streamCellsetRowsJSON(ctx, cellsetID, params, fn)
+	}
+
+	endpoint := fmt.Sprintf("/Cellsets('%s')/Axes?$select=Ordinal&$expand=Hierarchies($select=Name,UniqueName)", cellsetID)
+	if params.SandboxName != "" {
+		endpoint = addSandboxParam(endpoint, params.SandboxName)
+	}
+	var axes struct {
+		Value []Axis `json:"value"`
+	}
+	if err := cs.rest.JSON(ctx, http.MethodGet, endpoint, nil, &axes); err != nil {
+		return fmt.Errorf("get cellset axes: %w", err)
+	}
+	header := csvHeader(axes.Value)
+	if err := fn(header); err != nil {
+		return err
+	}
+
+	endpoint = fmt.Sprintf("/Cellsets('%s')/Content", cellsetID)
+	if params.SandboxName != "" {
+		endpoint = addSandboxParam(endpoint, params.SandboxName)
+	}
+	resp, err := cs.rest.Get(ctx, endpoint, WithHeader("Accept", "text/csv"))
+	if err != nil {
+		return fmt.Errorf("get cellset content: %w", err)
+	}
+	defer resp.Body.Close()
+
+	reader := csv.NewReader(resp.Body)
+	reader.FieldsPerRecord = len(header)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read cellset content: %w", err)
+		}
+		if params.SkipZeros && isZeroCSVValue(record[len(record)-1]) {
+			continue
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// streamCellsetRowsJSON builds the rows of StreamCellsetRows from the JSON cellset, requesting
+// only the axis members once and the cell properties needed for filtering.
+func (cs *CellService) streamCellsetRowsJSON(ctx context.Context, cellsetID string, params CSVParams, fn func(row []string) error) error {
+	selectClause := "Ordinal,Value"
+	if params.SkipConsolidated {
+		selectClause += ",Consolidated"
+	}
+	if params.SkipRuleDerived {
+		selectClause += ",RuleDerived"
+	}
+
+	endpoint := fmt.Sprintf("/Cellsets('%s')?$expand=Axes($select=Ordinal;$expand=Hierarchies($select=Name,UniqueName),Tuples($select=Ordinal;$expand=Members($select=Name))),Cells($select=%s)",
+		cellsetID, selectClause)
+	if params.SandboxName != "" {
+		endpoint = addSandboxParam(endpoint, params.SandboxName)
+	}
+
+	var cellset Cellset
+	if err := cs.rest.JSON(ctx, http.MethodGet, endpoint, nil, &cellset); err != nil {
+		return fmt.Errorf("get cellset: %w", err)
+	}
+
+	cellset.Axes = sortedAxes(cellset.Axes)
+	header := csvHeader(cellset.Axes)
+	if err := fn(header); err != nil {
+		return err
+	}
+
+	axisOrder := csvAxisOrder(len(cellset.Axes))
+	for _, cell := range cellset.Cells {
+		if params.SkipConsolidated && cell.Consolidated {
+			continue
+		}
+		if params.SkipRuleDerived && cell.RuleDerived {
+			continue
+		}
+		if params.SkipZeros && isZeroCellValue(cell.Value) {
+			continue
+		}
+
+		tupleIndexes := ordinalToTupleIndexes(cell.Ordinal, cellset.Axes)
+		row := make([]string, 0, len(header))
+		for _, axisIdx := range axisOrder {
+			axis := cellset.Axes[axisIdx]
+			tupleIdx := tupleIndexes[axisIdx]
+			if tupleIdx >= len(axis.Tuples) {
+				return fmt.Errorf("cell ordinal %d is out of range for axis %d", cell.Ordinal, axisIdx)
+			}
+			for _, member := range axis.Tuples[tupleIdx].Members {
+				row = append(row, member.Name)
+			}
+		}
+		row = append(row, formatCellValue(cell.Value))
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvAxisOrder returns the axis indexes in export order: rows, columns, then further axes.
+func csvAxisOrder(axisCount int) []int {
+	if axisCount == 1 {
+		return []int{0}
+	}
+	axisOrder := make([]int, 0, axisCount)
+	if axisCount > 1 {
+		axisOrder = append(axisOrder, 1, 0)
+		for i := 2; i < axisCount; i++ {
+			axisOrder = append(axisOrder, i)
+		}
+	}
+	return axisOrder
+}
+
+// csvHeader returns the header row for the axes of a cellset, taken in axis ordinal order.
+func csvHeader(axes []Axis) []string {
+	axes = sortedAxes(axes)
+	header := make([]string, 0)
+	for _, axisIdx := range csvAxisOrder(len(axes)) {
+		for _, hierarchy := range axes[axisIdx].Hierarchies {
+			header = append(header, hierarchyColumnName(hierarchy))
+		}
+	}
+	return append(header, "Value")
+}
+
+// sortedAxes returns a copy of axes sorted by axis ordinal.
+func sortedAxes(axes []Axis) []Axis {
+	sorted := append([]Axis(nil), axes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Ordinal < sorted[j].Ordinal })
+	return sorted
+}
+
+// ordinalToTupleIndexes converts a cell ordinal to the tuple index on each axis.
+// TM1 numbers cells with the first axis (columns) varying fastest.
+func ordinalToTupleIndexes(ordinal int, axes []Axis) []int {
+	indexes := make([]int, len(axes))
+	remaining := ordinal
+	for i, axis := range axes {
+		cardinality := len(axis.Tuples)
+		if cardinality == 0 {
+			continue
+		}
+		indexes[i] = remaining % cardinality
+		remaining /= cardinality
+	}
+	return indexes
+}
+
+// hierarchyColumnName returns the dimension name for a hierarchy, qualified with the
+// hierarchy name when it is an alternate hierarchy.
+func hierarchyColumnName(hierarchy Hierarchy) string {
+	parts := splitUniqueName(hierarchy.UniqueName)
+	if len(parts) != 2 {
+		return hierarchy.Name
+	}
+	if caseAndSpaceInsensitiveEquals(parts[0], parts[1]) {
+		return parts[0]
+	}
+	return parts[0] + ":" + parts[1]
+}
+
+func isZeroCellValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	}
+	return false
+}
+
+func formatCellValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// isZeroCSVValue reports whether an exported value is empty or a numeric zero.
+func isZeroCSVValue(value string) bool {
+	if value == "" {
+		return true
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	return err == nil && f == 0
+}
@@ -0,0 +1,199 @@
+package tm1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const csvTestCellset = `{"ID":"abc",
+"Axes":[
+ {"Ordinal":0,"Hierarchies":[{"Name":"Measure","UniqueName":"[Measure].[Measure]"}],"Tuples":[{"Ordinal":0,"Members":[{"Name":"Units"}]},{"Ordinal":1,"Members":[{"Name":"Price"}]}]},
+ {"Ordinal":1,"Hierarchies":[{"Name":"By Country","UniqueName":"[Region].[By Country]"}],"Tuples":[{"Ordinal":0,"Members":[{"Name":"Europe"}]},{"Ordinal":1,"Members":[{"Name":"Asia"}]}]}
+],
+"Cells":[
+ {"Ordinal":0,"Value":10,"Consolidated":true,"RuleDerived":false},
+ {"Ordinal":1,"Value":1.5,"Consolidated":true,"RuleDerived":true},
+ {"Ordinal":2,"Value":0,"Consolidated":false,"RuleDerived":false},
+ {"Ordinal":3,"Value":2.25,"Consolidated":false,"RuleDerived":false}
+]}`
+
+const csvTestAxes = `{"value":[
+ {"Ordinal":1,"Hierarchies":[{"Name":"By Country","UniqueName":"[Region].[By Country]"}]},
+ {"Ordinal":0,"Hierarchies":[{"Name":"Measure","UniqueName":"[Measure].[Measure]"}]}
+]}`
+
+const csvTestContent = "Europe,Units,10\r\nEurope,Price,1.5\r\nAsia,Units,0\r\n\"Asia\",Price,2.25\r\n"
+
+func newCSVTestService(t *testing.T, queries *[]string) (*CellService, func()) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/ExecuteMDX":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"ID":"abc"}`))
+		case r.Method == "GET" && r.URL.Path == "/Cellsets('abc')/Axes":
+			*queries = append(*queries, "Axes")
+			_, _ = w.Write([]byte(csvTestAxes))
+		case r.Method == "GET" && r.URL.Path == "/Cellsets('abc')/Content":
+			*queries = append(*queries, "Content "+r.Header.Get("Accept"))
+			_, _ = w.Write([]byte(csvTestContent))
+		case r.Method == "GET" && r.URL.Path == "/Cellsets('abc')":
+			rawQuery, _ := url.QueryUnescape(r.URL.RawQuery)
+			*queries = append(*queries, rawQuery)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(csvTestCellset))
+		case r.Method == "DELETE" && r.URL.Path == "/Cellsets('abc')":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rest, _ := NewRestService(cfg)
+	mockBaseURL, _ := url.Parse(server.URL)
+	rest.SetBaseURL(mockBaseURL.String())
+
+	return NewCellService(rest), server.Close
+}
+
+func TestCellServiceExecuteMDXCSV(t *testing.T) {
+	var queries []string
+	service, closeServer := newCSVTestService(t, &queries)
+	defer closeServer()
+
+	csvText, err := service.ExecuteMDXCSV(context.Background(), "SELECT ...", CSVParams{Delimiter: ';'})
+	if err != nil {
+		t.Fatalf("ExecuteMDXCSV() error = %v", err)
+	}
+
+	want := "Region:By Country;Measure;Value\nEurope;Units;10\nEurope;Price;1.5\nAsia;Units;0\nAsia;Price;2.25\n"
+	if csvText != want {
+		t.Fatalf("ExecuteMDXCSV() = %q, want %q", csvText, want)
+	}
+	if strings.Join(queries, "|") != "Axes|Content text/csv" {
+		t.Fatalf("unexpected requests: %v", queries)
+	}
+}
+
+func TestCellServiceExecuteMDXRowsSkipOptions(t *testing.T) {
+	var queries []string
+	service, closeServer := newCSVTestService(t, &queries)
+	defer closeServer()
+
+	rows, err := service.ExecuteMDXRows(context.Background(), "SELECT ...", CSVParams{SkipZeros: true, SkipConsolidated: true})
+	if err != nil {
+		t.Fatalf("ExecuteMDXRows() error = %v", err)
+	}
+	if len(rows) != 2 || strings.Join(rows[1], ",") != "Asia,Price,2.25" {
+		t.Fatalf("ExecuteMDXRows() = %v", rows)
+	}
+	if !strings.Contains(queries[0], "Cells($select=Ordinal,Value,Consolidated)") {
+		t.Fatalf("unexpected $expand: %v", queries)
+	}
+
+	rows, err = service.ExecuteMDXRows(context.Background(), "SELECT ...", CSVParams{SkipRuleDerived: true})
+	if err != nil {
+		t.Fatalf("ExecuteMDXRows() error = %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("ExecuteMDXRows() with SkipRuleDerived = %v", rows)
+	}
+}
+
+func TestCellServiceExecuteMDXDataFrameWithCSV(t *testing.T) {
+	var queries []string
+	service, closeServer := newCSVTestService(t, &queries)
+	defer closeServer()
+
+	df, err := service.ExecuteMDXDataFrame(context.Background(), "SELECT ...", nil, "", []string{"Region"}, WithCSVExtraction(CSVParams{}))
+	if err != nil {
+		t.Fatalf("ExecuteMDXDataFrame() error = %v", err)
+	}
+	if df.Nrow() != 4 {
+		t.Fatalf("Nrow() = %d, want 4", df.Nrow())
+	}
+	names := df.Names()
+	if strings.Join(names, ",") != "Region,Measure,Value" {
+		t.Fatalf("Names() = %v", names)
+	}
+	if got := df.Col("Value").Elem(3).Float(); got != 2.25 {
+		t.Fatalf("Value[3] = %v, want 2.25", got)
+	}
+}
+
+func TestCellServiceStreamCellsetRows(t *testing.T) {
+	var queries []string
+	service, closeServer := newCSVTestService(t, &queries)
+	defer closeServer()
+
+	var rows []string
+	err := service.StreamCellsetRows(context.Background(), "abc", false, CSVParams{SkipZeros: true}, func(row []string) error {
+		rows = append(rows, strings.Join(row, ","))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCellsetRows() error = %v", err)
+	}
+	want := "Region:By Country,Measure,Value|Europe,Units,10|Europe,Price,1.5|Asia,Price,2.25"
+	if strings.Join(rows, "|") != want {
+		t.Fatalf("rows = %v", rows)
+	}
+
+	stop := errors.New("stop")
+	err = service.StreamCellsetRows(context.Background(), "abc", false, CSVParams{}, func(row []string) error {
+		if row[0] == "Europe" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("StreamCellsetRows() error = %v, want stop", err)
+	}
+}
+
+func TestCellServiceStreamCellsetRowsDeleteError(t *testing.T) {
+	service := newTestCellService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/Cellsets('abc')/Axes":
+			_, _ = w.Write([]byte(csvTestAxes))
+		case r.Method == http.MethodGet && r.URL.Path == "/Cellsets('abc')/Content":
+			_, _ = w.Write([]byte(csvTestContent))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	rows := 0
+	err := service.StreamCellsetRows(context.Background(), "abc", true, CSVParams{}, func(row []string) error {
+		rows++
+		return nil
+	})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError || !strings.Contains(err.Error(), "delete cellset") {
+		t.Fatalf("StreamCellsetRows() error = %v, want delete failure", err)
+	}
+	if rows != 5 {
+		t.Errorf("rows = %d, want header and 4 rows", rows)
+	}
+}
+
+func TestCSVHeaderKeepsAxisOrder(t *testing.T) {
+	axes := []Axis{
+		{Ordinal: 1, Hierarchies: []Hierarchy{{Name: "Region", UniqueName: "[Region].[Region]"}}},
+		{Ordinal: 0, Hierarchies: []Hierarchy{{Name: "Measure", UniqueName: "[Measure].[Measure]"}}},
+	}
+	if header := strings.Join(csvHeader(axes), ","); header != "Region,Measure,Value" {
+		t.Errorf("csvHeader() = %s", header)
+	}
+	if axes[0].Ordinal != 1 {
+		t.Errorf("csvHeader() reordered the caller's axes")
+	}
+}
@@ -0,0 +1,208 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultCellsetPageSize is the number of cells requested per page when streaming a cellset.
+const DefaultCellsetPageSize = 10000
+
+// StreamParams controls how a cellset is read by a CellsetStream.
+type StreamParams struct {
+	PageSize       int      // Cells requested per round trip (default: DefaultCellsetPageSize)
+	CellProperties []string // Cell properties to select (default: Ordinal,Value)
+	SandboxName    string   // Sandbox to read from
+	KeepCellset    bool     // Do not delete the cellset when the stream is closed
+}
+
+// CellsetRecord is a single cell yielded by a CellsetStream.
+type CellsetRecord struct {
+	Ordinal  int         // Cell ordinal within the cellset
+	Elements []string    // Element names, one per hierarchy in axis order
+	Value    interface{} // Cell value
+	Cell     Cell        // Raw cell including the requested properties
+}
+
+// CellsetStream reads the cells of a cellset page by page using $top and $skip,
+// so that memory usage is bounded by the page size regardless of the cellset size.
+//
+// Typical usage:
+//
+//	stream, err := cs.ExecuteMDXStream(ctx, mdx, StreamParams{})
+//	if err != nil { ... }
+//	defer stream.Close()
+//	for stream.Next(ctx) {
+//		record := stream.Record()
+//		...
+//	}
+//	if err := stream.Err(); err != nil { ... }
+type CellsetStream struct {
+	cs        *CellService
+	cellsetID string
+	params    StreamParams
+
+	hierarchies []string // Hierarchy unique names in axis order, loaded lazily
+	page        []Cell
+	pos         int
+	skip        int
+	done        bool
+	closed      bool
+	record      CellsetRecord
+	err         error
+}
+
+// ExecuteMDXStream executes an MDX query and returns a stream over its cells.
+// The caller must Close the stream to release the cellset on the server.
+func (cs *CellService) ExecuteMDXStream(ctx context.Context, mdx string, params StreamParams) (*CellsetStream, error) {
+	cellsetID, err := cs.CreateCellset(ctx, mdx, params.SandboxName)
+	if err != nil {
+		return nil, fmt.Errorf("create cellset: %w", err)
+	}
+	return cs.StreamCellset(cellsetID, params), nil
+}
+
+// ExecuteViewStream executes a cube view and returns a stream over its cells.
+// The caller must Close the stream to release the cellset on the server.
+func (cs *CellService) ExecuteViewStream(ctx context.Context, cubeName, viewName string, private bool, params StreamParams) (*CellsetStream, error) {
+	cellsetID, err := cs.CreateCellsetFromView(ctx, cubeName, viewName, private, params.SandboxName)
+	if err != nil {
+		return nil, fmt.Errorf("create cellset from view: %w", err)
+	}
+	return cs.StreamCellset(cellsetID, params), nil
+}
+
+// StreamCellset returns a stream over the cells of an existing cellset.
+func (cs *CellService) StreamCellset(cellsetID string, params StreamParams) *CellsetStream {
+	if params.PageSize <= 0 {
+		params.PageSize = DefaultCellsetPageSize
+	}
+	return &CellsetStream{cs: cs, cellsetID: cellsetID, params: params}
+}
+
+// CellsetID returns the ID of the underlying cellset.
+func (s *CellsetStream) CellsetID() string {
+	return s.cellsetID
+}
+
+// Next advances the stream to the next cell, fetching a new page when required.
+// It returns false when all cells have been read or an error occurred.
+func (s *CellsetStream) Next(ctx context.Context) bool {
+	if s.err != nil || s.closed {
+		return false
+	}
+	if s.pos >= len(s.page) {
+		if s.done {
+			return false
+		}
+		if err := s.fetchPage(ctx); err != nil {
+			s.err = err
+			return false
+		}
+		if len(s.page) == 0 {
+			return false
+		}
+	}
+
+	cell := s.page[s.pos]
+	s.pos++
+
+	elements := make([]string, len(cell.Members))
+	for i, member := range cell.Members {
+		elements[i] = member.Name
+		if elements[i] == "" {
+			elements[i] = member.UniqueName
+		}
+	}
+	value := cell.Value
+	if value == nil {
+		value = 0
+	}
+	s.record = CellsetRecord{Ordinal: cell.Ordinal, Elements: elements, Value: value, Cell: cell}
+	return true
+}
+
+// Record returns the cell the stream is currently positioned on.
+func (s *CellsetStream) Record() CellsetRecord {
+	return s.record
+}
+
+// Err returns the first error encountered while reading the stream.
+func (s *CellsetStream) Err() error {
+	return s.err
+}
+
+// Hierarchies returns the unique names of the hierarchies on the cellset axes, in the same
+// order as CellsetRecord.Elements.
+func (s *CellsetStream) Hierarchies(ctx context.Context) ([]string, error) {
+	if s.hierarchies != nil {
+		return s.hierarchies, nil
+	}
+
+	endpoint := fmt.Sprintf("/Cellsets('%s')/Axes?$select=Ordinal&$expand=Hierarchies($select=Name,UniqueName)", s.cellsetID)
+	if s.params.SandboxName != "" {
+		endpoint = addSandboxParam(endpoint, s.params.SandboxName)
+	}
+
+	var response struct {
+		Value []Axis `json:"value"`
+	}
+	if err := s.cs.rest.JSON(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("get cellset axes: %w", err)
+	}
+
+	hierarchies := make([]string, 0)
+	for _, axis := range response.Value {
+		for _, hierarchy := range axis.Hierarchies {
+			hierarchies = append(hierarchies, hierarchy.UniqueName)
+		}
+	}
+	s.hierarchies = hierarchies
+	return hierarchies, nil
+}
+
+// Close deletes the cellset unless KeepCellset is set. It is safe to call Close more than once.
+func (s *CellsetStream) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.page = nil
+	if s.params.KeepCellset {
+		return nil
+	}
+	return s.cs.DeleteCellset(context.Background(), s.cellsetID, s.params.SandboxName)
+}
+
+func (s *CellsetStream) fetchPage(ctx context.Context) error {
+	selectClause := "Ordinal,Value"
+	if len(s.params.CellProperties) > 0 {
+		selectClause = strings.Join(s.params.CellProperties, ",")
+		if !contains(s.params.CellProperties, "Ordinal") {
+			selectClause = "Ordinal," + selectClause
+		}
+	}
+
+	endpoint := fmt.Sprintf("/Cellsets('%s')/Cells?$select=%s&$expand=Members($select=Name,UniqueName)&$top=%d&$skip=%d",
+		s.cellsetID, selectClause, s.params.PageSize, s.skip)
+	if s.params.SandboxName != "" {
+		endpoint = addSandboxParam(endpoint, s.params.SandboxName)
+	}
+
+	var response struct {
+		Value []Cell `json:"value"`
+	}
+	if err := s.cs.rest.JSON(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return fmt.Errorf("get cellset cells: %w", err)
+	}
+
+	s.page = response.Value
+	s.pos = 0
+	s.skip += len(response.Value)
+	if len(response.Value) < s.params.PageSize {
+		s.done = true
+	}
+	return nil
+}
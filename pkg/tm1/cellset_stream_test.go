@@ -0,0 +1,122 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestCellsetStreamPaginates(t *testing.T) {
+	const totalCells = 5
+	skips := make([]string, 0)
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/ExecuteMDX":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"ID":"abc"}`))
+		case r.Method == "GET" && r.URL.Path == "/Cellsets('abc')/Cells":
+			query := r.URL.Query()
+			skips = append(skips, query.Get("$skip"))
+			top, _ := strconv.Atoi(query.Get("$top"))
+			skip, _ := strconv.Atoi(query.Get("$skip"))
+			body := `{"value":[`
+			for i := skip; i < skip+top && i < totalCells; i++ {
+				if i > skip {
+					body += ","
+				}
+				body += fmt.Sprintf(`{"Ordinal":%d,"Value":%d,"Members":[{"Name":"Units"},{"Name":"e%d"}]}`, i, i*10, i)
+			}
+			body += `]}`
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(body))
+		case r.Method == "GET" && r.URL.Path == "/Cellsets('abc')/Axes":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"value":[{"Ordinal":0,"Hierarchies":[{"Name":"Measure","UniqueName":"[Measure].[Measure]"}]},{"Ordinal":1,"Hierarchies":[{"Name":"Region","UniqueName":"[Region].[Region]"}]}]}`))
+		case r.Method == "DELETE" && r.URL.Path == "/Cellsets('abc')":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rest, _ := NewRestService(cfg)
+	mockBaseURL, _ := url.Parse(server.URL)
+	rest.SetBaseURL(mockBaseURL.String())
+
+	service := NewCellService(rest)
+	ctx := context.Background()
+	stream, err := service.ExecuteMDXStream(ctx, "SELECT ...", StreamParams{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ExecuteMDXStream() error = %v", err)
+	}
+
+	hierarchies, err := stream.Hierarchies(ctx)
+	if err != nil {
+		t.Fatalf("Hierarchies() error = %v", err)
+	}
+	if len(hierarchies) != 2 || hierarchies[1] != "[Region].[Region]" {
+		t.Fatalf("Hierarchies() = %v", hierarchies)
+	}
+
+	count := 0
+	for stream.Next(ctx) {
+		record := stream.Record()
+		if record.Ordinal != count || record.Value != float64(count*10) {
+			t.Fatalf("record %d = %#v", count, record)
+		}
+		if len(record.Elements) != 2 || record.Elements[1] != fmt.Sprintf("e%d", count) {
+			t.Fatalf("record %d elements = %v", count, record.Elements)
+		}
+		count++
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if count != totalCells {
+		t.Fatalf("streamed %d cells, want %d", count, totalCells)
+	}
+	if want := []string{"0", "2", "4"}; fmt.Sprint(skips) != fmt.Sprint(want) {
+		t.Fatalf("$skip values = %v, want %v", skips, want)
+	}
+
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !deleted {
+		t.Fatal("Close() did not delete the cellset")
+	}
+	if stream.Next(ctx) {
+		t.Fatal("Next() after Close() = true, want false")
+	}
+}
+
+func TestCellsetStreamReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rest, _ := NewRestService(cfg)
+	mockBaseURL, _ := url.Parse(server.URL)
+	rest.SetBaseURL(mockBaseURL.String())
+
+	stream := NewCellService(rest).StreamCellset("abc", StreamParams{KeepCellset: true})
+	if stream.Next(context.Background()) {
+		t.Fatal("Next() = true, want false")
+	}
+	if stream.Err() == nil {
+		t.Fatal("Err() = nil, want error")
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package tm1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func newTestCellset(t *testing.T) *Cellset {
+	t.Helper()
+	payload := `{"ID":"abc",
+"Axes":[
+ {"Ordinal":0,"Tuples":[{"Ordinal":0,"Members":[{"Name":"Units","UniqueName":"[Measure].[Measure].[Units]"}]},{"Ordinal":1,"Members":[{"Name":"Price","UniqueName":"[Measure].[Measure].[Price]"}]}]},
+ {"Ordinal":1,"Tuples":[
+  {"Ordinal":0,"Members":[{"Name":"Paris, FR","UniqueName":"[Region].[By Country].[Europe]^[Paris, FR]"},{"Name":"2024","UniqueName":"[Year].[Year].[2024]"}]},
+  {"Ordinal":1,"Members":[{"Name":"Tokyo","UniqueName":"[Region].[By Country].[Tokyo]"},{"Name":"2024","UniqueName":"[Year].[Year].[2024]"}]},
+  {"Ordinal":2,"Members":[{"Name":"Tokyo","UniqueName":"[Region].[By Country].[Tokyo]"},{"Name":"2025","UniqueName":"[Year].[Year].[2025]"}]}
+ ]},
+ {"Ordinal":2,"Tuples":[{"Ordinal":0,"Members":[{"Name":"Actual","UniqueName":"[Version].[Version].[Actual]"}]}]}
+],
+"Cells":[
+ {"Ordinal":0,"Value":1},{"Ordinal":1,"Value":10},
+ {"Ordinal":2,"Value":2},{"Ordinal":3,"Value":20},
+ {"Ordinal":4,"Value":3},{"Ordinal":5,"Value":30}
+]}`
+	var cellset Cellset
+	if err := json.Unmarshal([]byte(payload), &cellset); err != nil {
+		t.Fatalf("unmarshal cellset: %v", err)
+	}
+	return &cellset
+}
+
+func TestCellsetCell(t *testing.T) {
+	cellset := newTestCellset(t)
+
+	if cellset.RowCount() != 3 || cellset.ColumnCount() != 2 {
+		t.Fatalf("RowCount, ColumnCount = %d, %d, want 3, 2", cellset.RowCount(), cellset.ColumnCount())
+	}
+
+	cell, ok := cellset.Cell(1, 1)
+	if !ok || cell.Value != float64(20) {
+		t.Fatalf("Cell(1, 1) = %v, %v, want 20", cell, ok)
+	}
+	if _, ok := cellset.Cell(3, 0); ok {
+		t.Fatal("Cell(3, 0) found, want out of range")
+	}
+}
+
+func TestCellsetLookup(t *testing.T) {
+	cellset := newTestCellset(t)
+
+	tests := []struct {
+		name        string
+		coordinates map[string]string
+		want        interface{}
+		found       bool
+	}{
+		{name: "element with comma", coordinates: map[string]string{"Region": "Paris, FR", "Year": "2024", "Measure": "Price"}, want: float64(10), found: true},
+		{name: "hierarchy qualified", coordinates: map[string]string{"Region:By Country": "tokyo", "year": "2025", "Measure": "Units"}, want: float64(3), found: true},
+		{name: "slicer addressed", coordinates: map[string]string{"Region": "Tokyo", "Year": "2024", "Measure": "Units", "Version": "Actual"}, want: float64(2), found: true},
+		{name: "wrong hierarchy", coordinates: map[string]string{"Region:Region": "Tokyo", "Year": "2024", "Measure": "Units"}},
+		{name: "missing dimension", coordinates: map[string]string{"Region": "Tokyo", "Measure": "Units"}},
+		{name: "unknown element", coordinates: map[string]string{"Region": "Berlin", "Year": "2024", "Measure": "Units"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cell, ok := cellset.Lookup(tt.coordinates)
+			if ok != tt.found {
+				t.Fatalf("Lookup() found = %v, want %v", ok, tt.found)
+			}
+			if ok && cell.Value != tt.want {
+				t.Fatalf("Lookup() value = %v, want %v", cell.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellsetRowsAndMatrix(t *testing.T) {
+	cellset := newTestCellset(t)
+
+	rows := cellset.Rows()
+	if len(rows) != 3 {
+		t.Fatalf("Rows() len = %d, want 3", len(rows))
+	}
+	if rows[2].Tuple.Members[1].Name != "2025" || rows[2].Cells[1].Value != float64(30) {
+		t.Fatalf("Rows()[2] = %#v", rows[2])
+	}
+
+	want := [][]interface{}{{float64(1), float64(10)}, {float64(2), float64(20)}, {float64(3), float64(30)}}
+	if got := cellset.ToMatrix(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToMatrix() = %v, want %v", got, want)
+	}
+
+	cellset.Cells = cellset.Cells[:3]
+	matrix := cellset.ToMatrix()
+	if matrix[1][0] != float64(2) || matrix[1][1] != nil {
+		t.Fatalf("ToMatrix() with missing cells = %v", matrix)
+	}
+}
@@ -28,7 +28,7 @@ func (cs *ChoreService) Get(ctx context.Context, choreName string) (*models.Chor
 	query := url.Values{}
 	query.Set("$expand", choreExpand)
 
-	endpoint := fmt.Sprintf("/Chores('%s')?%s", url.PathEscape(choreName), EncodeODataQuery(query))
+	endpoint := fmt.Sprintf("/Chores('%s')?%s", escapeODataKey(choreName), EncodeODataQuery(query))
 	var chore models.Chore
 	if err := cs.rest.JSON(ctx, "GET", endpoint, nil, &chore); err != nil {
 		return nil, err
@@ -101,7 +101,7 @@ func (cs *ChoreService) Create(ctx context.Context, chore *models.Chore) error {
 
 // Delete deletes a chore from TM1.
 func (cs *ChoreService) Delete(ctx context.Context, choreName string) error {
-	resp, err := cs.rest.Delete(ctx, fmt.Sprintf("/Chores('%s')", url.PathEscape(choreName)))
+	resp, err := cs.rest.Delete(ctx, fmt.Sprintf("/Chores('%s')", escapeODataKey(choreName)))
 	if err != nil {
 		return err
 	}
@@ -112,7 +112,7 @@ func (cs *ChoreService) Delete(ctx context.Context, choreName string) error {
 
 // Exists checks if a chore exists.
 func (cs *ChoreService) Exists(ctx context.Context, choreName string) (bool, error) {
-	endpoint := fmt.Sprintf("/Chores('%s')", url.PathEscape(choreName))
+	endpoint := fmt.Sprintf("/Chores('%s')", escapeODataKey(choreName))
 	resp, err := cs.rest.Get(ctx, endpoint)
 	if err != nil {
 		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
@@ -176,7 +176,7 @@ func (cs *ChoreService) Update(ctx context.Context, chore *models.Chore) error {
 			"ExecutionMode": chore.ExecutionMode,
 			"Frequency":     chore.Frequency,
 		}
-		endpoint := fmt.Sprintf("/Chores('%s')", url.PathEscape(chore.Name))
+		endpoint := fmt.Sprintf("/Chores('%s')", escapeODataKey(chore.Name))
 		if err := cs.rest.JSON(ctx, "PATCH", endpoint, patchBody, nil); err != nil {
 			return err
 		}
@@ -241,7 +241,7 @@ func (cs *ChoreService) UpdateOrCreate(ctx context.Context, chore *models.Chore)
 
 // Activate activates a chore.
 func (cs *ChoreService) Activate(ctx context.Context, choreName string) error {
-	resp, err := cs.rest.Post(ctx, fmt.Sprintf("/Chores('%s')/tm1.Activate", url.PathEscape(choreName)), strings.NewReader(""))
+	resp, err := cs.rest.Post(ctx, fmt.Sprintf("/Chores('%s')/tm1.Activate", escapeODataKey(choreName)), strings.NewReader(""))
 	if err != nil {
 		return err
 	}
@@ -252,7 +252,7 @@ func (cs *ChoreService) Activate(ctx context.Context, choreName string) error {
 
 // Deactivate deactivates a chore.
 func (cs *ChoreService) Deactivate(ctx context.Context, choreName string) error {
-	resp, err := cs.rest.Post(ctx, fmt.Sprintf("/Chores('%s')/tm1.Deactivate", url.PathEscape(choreName)), strings.NewReader(""))
+	resp, err := cs.rest.Post(ctx, fmt.Sprintf("/Chores('%s')/tm1.Deactivate", escapeODataKey(choreName)), strings.NewReader(""))
 	if err != nil {
 		return err
 	}
@@ -274,12 +274,12 @@ func (cs *ChoreService) setLocalStartTimeUnsafe(ctx context.Context, choreName s
 		"StartTime": fmt.Sprintf("%02d:%02d:%02d", dt.Hour(), dt.Minute(), dt.Second()),
 	}
 
-	return cs.rest.JSON(ctx, "POST", fmt.Sprintf("/Chores('%s')/tm1.SetServerLocalStartTime", url.PathEscape(choreName)), data, nil)
+	return cs.rest.JSON(ctx, "POST", fmt.Sprintf("/Chores('%s')/tm1.SetServerLocalStartTime", escapeODataKey(choreName)), data, nil)
 }
 
 // ExecuteChore executes a chore.
 func (cs *ChoreService) ExecuteChore(ctx context.Context, choreName string) error {
-	resp, err := cs.rest.Post(ctx, fmt.Sprintf("/Chores('%s')/tm1.Execute", url.PathEscape(choreName)), strings.NewReader(""))
+	resp, err := cs.rest.Post(ctx, fmt.Sprintf("/Chores('%s')/tm1.Execute", escapeODataKey(choreName)), strings.NewReader(""))
 	if err != nil {
 		return err
 	}
@@ -289,7 +289,7 @@ func (cs *ChoreService) ExecuteChore(ctx context.Context, choreName string) erro
 }
 
 func (cs *ChoreService) getTasksCount(ctx context.Context, choreName string) (int, error) {
-	resp, err := cs.rest.Get(ctx, fmt.Sprintf("/Chores('%s')/Tasks/$count", url.PathEscape(choreName)))
+	resp, err := cs.rest.Get(ctx, fmt.Sprintf("/Chores('%s')/Tasks/$count", escapeODataKey(choreName)))
 	if err != nil {
 		return 0, err
 	}
@@ -305,7 +305,7 @@ func (cs *ChoreService) getTasksCount(ctx context.Context, choreName string) (in
 func (cs *ChoreService) getTask(ctx context.Context, choreName string, step int) (*models.ChoreTask, error) {
 	query := url.Values{}
 	query.Set("$expand", "*,Process($select=Name),Chore($select=Name)")
-	endpoint := fmt.Sprintf("/Chores('%s')/Tasks(%d)?%s", url.PathEscape(choreName), step, EncodeODataQuery(query))
+	endpoint := fmt.Sprintf("/Chores('%s')/Tasks(%d)?%s", escapeODataKey(choreName), step, EncodeODataQuery(query))
 
 	var task models.ChoreTask
 	if err := cs.rest.JSON(ctx, "GET", endpoint, nil, &task); err != nil {
@@ -315,7 +315,7 @@ func (cs *ChoreService) getTask(ctx context.Context, choreName string, step int)
 }
 
 func (cs *ChoreService) deleteTask(ctx context.Context, choreName string, step int) error {
-	resp, err := cs.rest.Delete(ctx, fmt.Sprintf("/Chores('%s')/Tasks(%d)", url.PathEscape(choreName), step))
+	resp, err := cs.rest.Delete(ctx, fmt.Sprintf("/Chores('%s')/Tasks(%d)", escapeODataKey(choreName), step))
 	if err != nil {
 		return err
 	}
@@ -326,11 +326,11 @@ func (cs *ChoreService) deleteTask(ctx context.Context, choreName string, step i
 
 func (cs *ChoreService) addTask(ctx context.Context, choreName string, task models.ChoreTask) error {
 	body := task.ToRequestBody()
-	return cs.rest.JSON(ctx, "POST", fmt.Sprintf("/Chores('%s')/Tasks", url.PathEscape(choreName)), body, nil)
+	return cs.rest.JSON(ctx, "POST", fmt.Sprintf("/Chores('%s')/Tasks", escapeODataKey(choreName)), body, nil)
 }
 
 func (cs *ChoreService) updateTask(ctx context.Context, choreName string, task models.ChoreTask) error {
-	endpoint := fmt.Sprintf("/Chores('%s')/Tasks(%d)", url.PathEscape(choreName), task.Step)
+	endpoint := fmt.Sprintf("/Chores('%s')/Tasks(%d)", escapeODataKey(choreName), task.Step)
 	body := task.ToRequestBody()
 	return cs.rest.JSON(ctx, "PATCH", endpoint, body, nil)
 }
@@ -8,6 +8,7 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -49,7 +50,10 @@ type Config struct {
 	IntegratedLoginDomain   string // NT Domain name. Default: '.' for local account
 	IntegratedLoginService  string // Kerberos Service type for remote Service Principal Name. Default: 'HTTP'
 	IntegratedLoginHost     string // Host name for Service Principal Name. Default: Extracted from request URI
-	IntegratedLoginDelegate bool   // Indicates that the user's credentials are to be delegated to the server
+	IntegratedLoginDelegate bool   // Indicates that the user's credentials are to be delegated to the server (not supported: NewRestService returns an error)
+	IntegratedLoginKeytab   string // Optional keytab file; logs in as User instead of using the credential cache
+	IntegratedLoginCCache   string // Kerberos credential cache. Default: KRB5CCNAME or /tmp/krb5cc_<uid>
+	IntegratedLoginKrb5Conf string // Kerberos configuration file. Default: KRB5_CONFIG or /etc/krb5.conf
 
 	// Request behavior parameters
 	Timeout             time.Duration // Per-request timeout applied to the HTTP client
@@ -60,6 +64,8 @@ type Config struct {
 	Verify              interface{}   // Path to .cer file or boolean for SSL verification
 	SkipSSLVerification bool          // Allows self-signed certificates during development
 	Logging             bool          // Switch on/off verbose http logging
+	RetryPolicy         *RetryPolicy  // Optional retry policy for transient errors (5xx, server busy, connection resets)
+	ValidateModels      bool          // Check dimensions and hierarchies with their Validate method before Create and Update
 
 	// Connection pool parameters
 	ConnectionPoolSize int // Maximum number of connections to save in the pool (default: 10)
@@ -229,7 +235,7 @@ func (c Config) HTTPClientOrDefault() (*http.Client, error) {
 	}
 
 	// Create cookie jar to store session cookies
-	jar, err := cookiejar.New(nil)
+	jar, err := newSessionJar()
 	if err != nil {
 		return nil, fmt.Errorf("create cookie jar: %w", err)
 	}
@@ -291,3 +297,44 @@ func (c Config) getProxyFunc() func(*http.Request) (*url.URL, error) {
 	// Default to environment proxy
 	return http.ProxyFromEnvironment
 }
+
+// sessionJar is a cookie jar that can drop all stored cookies, which forces
+// TM1 to establish a new session on the next request after re-authentication.
+type sessionJar struct {
+	mu  sync.RWMutex
+	jar http.CookieJar
+}
+
+func newSessionJar() (*sessionJar, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionJar{jar: jar}, nil
+}
+
+// SetCookies implements http.CookieJar.
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	j.jar.SetCookies(u, cookies)
+}
+
+// Cookies implements http.CookieJar.
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.jar.Cookies(u)
+}
+
+// Reset discards all stored cookies.
+func (j *sessionJar) Reset() error {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	j.jar = jar
+	j.mu.Unlock()
+	return nil
+}
@@ -46,7 +46,7 @@ func (cs *CubeService) Create(ctx context.Context, cube *models.Cube) error {
 
 // Get retrieves a cube by name
 func (cs *CubeService) Get(ctx context.Context, cubeName string) (*models.Cube, error) {
-	endpoint := fmt.Sprintf("/Cubes('%s')?$expand=Dimensions($select=Name)", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')?$expand=Dimensions($select=Name)", escapeODataKey(cubeName))
 
 	var cube models.Cube
 	err := cs.rest.JSON(ctx, "GET", endpoint, nil, &cube)
@@ -64,7 +64,7 @@ func (cs *CubeService) Get(ctx context.Context, cubeName string) (*models.Cube,
 
 // GetLastDataUpdate retrieves the cube's last data update timestamp as a string
 func (cs *CubeService) GetLastDataUpdate(ctx context.Context, cubeName string) (string, error) {
-	endpoint := fmt.Sprintf("/Cubes('%s')/LastDataUpdate/$value", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/LastDataUpdate/$value", escapeODataKey(cubeName))
 
 	resp, err := cs.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -171,7 +171,7 @@ func (cs *CubeService) GetNumberOfCubes(ctx context.Context, skipControlCube boo
 
 // GetMeasureDimension retrieves the last dimension (measure) for a cube
 func (cs *CubeService) GetMeasureDimension(ctx context.Context, cubeName string) (*models.Dimension, error) {
-	endpoint := fmt.Sprintf("/Cubes('%s')/Dimensions?$select=Name", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/Dimensions?$select=Name", escapeODataKey(cubeName))
 
 	var response struct {
 		Value []models.Dimension `json:"value"`
@@ -196,7 +196,7 @@ func (cs *CubeService) Update(ctx context.Context, cube *models.Cube) error {
 		return fmt.Errorf("failed to build cube body: %w", err)
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')", url.PathEscape(cube.Name))
+	endpoint := fmt.Sprintf("/Cubes('%s')", escapeODataKey(cube.Name))
 	resp, err := cs.rest.Patch(ctx, endpoint, strings.NewReader(body))
 	if err != nil {
 		return err
@@ -207,6 +207,16 @@ func (cs *CubeService) Update(ctx context.Context, cube *models.Cube) error {
 	return nil
 }
 
+// UpdateDrillthroughRules replaces the drillthrough rules of a cube. An empty string removes them.
+func (cs *CubeService) UpdateDrillthroughRules(ctx context.Context, cubeName string, rules string) error {
+	endpoint := fmt.Sprintf("/Cubes('%s')", escapeODataKey(cubeName))
+	payload := map[string]string{"DrillthroughRules": rules}
+	if err := cs.rest.JSON(ctx, "PATCH", endpoint, payload, nil); err != nil {
+		return fmt.Errorf("update drillthrough rules: %w", err)
+	}
+	return nil
+}
+
 // UpdateOrCreate updates a cube if it exists, otherwise creates it
 func (cs *CubeService) UpdateOrCreate(ctx context.Context, cube *models.Cube) error {
 	exists, err := cs.Exists(ctx, cube.Name)
@@ -223,7 +233,7 @@ func (cs *CubeService) UpdateOrCreate(ctx context.Context, cube *models.Cube) er
 
 // CheckRules checks rules syntax for a cube
 func (cs *CubeService) CheckRules(ctx context.Context, cubeName string) ([]models.RuleSyntaxError, error) {
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.CheckRules", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.CheckRules", escapeODataKey(cubeName))
 
 	var response struct {
 		Value []models.RuleSyntaxError `json:"value"`
@@ -247,7 +257,7 @@ func (cs *CubeService) Delete(ctx context.Context, cubeName string) error {
 		return fmt.Errorf("Delete requires Data Admin privilege")
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')", escapeODataKey(cubeName))
 	resp, err := cs.rest.Delete(ctx, endpoint)
 	if err != nil {
 		return err
@@ -259,7 +269,7 @@ func (cs *CubeService) Delete(ctx context.Context, cubeName string) error {
 
 // Exists checks if a cube exists in TM1
 func (cs *CubeService) Exists(ctx context.Context, cubeName string) (bool, error) {
-	endpoint := fmt.Sprintf("/Cubes('%s')", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')", escapeODataKey(cubeName))
 	resp, err := cs.rest.Get(ctx, endpoint)
 	if err != nil {
 		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
@@ -362,7 +372,7 @@ func (cs *CubeService) GetAllNamesWithoutRules(ctx context.Context, skipControlC
 
 // GetDimensionNames retrieves the dimension names for a cube
 func (cs *CubeService) GetDimensionNames(ctx context.Context, cubeName string) ([]string, error) {
-	endpoint := fmt.Sprintf("/Cubes('%s')/Dimensions?$select=Name", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/Dimensions?$select=Name", escapeODataKey(cubeName))
 
 	var response struct {
 		Value []models.Dimension `json:"value"`
@@ -487,7 +497,7 @@ func (cs *CubeService) GetStorageDimensionOrder(ctx context.Context, cubeName st
 		return nil, fmt.Errorf("GetStorageDimensionOrder requires TM1 v11.4 or greater")
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.DimensionsStorageOrder()?$select=Name", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.DimensionsStorageOrder()?$select=Name", escapeODataKey(cubeName))
 
 	var response struct {
 		Value []models.Dimension `json:"value"`
@@ -520,7 +530,7 @@ func (cs *CubeService) UpdateStorageDimensionOrder(ctx context.Context, cubeName
 		return 0, fmt.Errorf("UpdateStorageDimensionOrder requires Data Admin privilege")
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.ReorderDimensions", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.ReorderDimensions", escapeODataKey(cubeName))
 
 	payload := map[string][]string{}
 	payload["Dimensions@odata.bind"] = make([]string, 0, len(dimensions))
@@ -570,7 +580,7 @@ func (cs *CubeService) Load(ctx context.Context, cubeName string) error {
 		return fmt.Errorf("Load requires Data Admin privilege")
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Load", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Load", escapeODataKey(cubeName))
 	resp, err := cs.rest.Post(ctx, endpoint, nil)
 	if err != nil {
 		return err
@@ -594,7 +604,7 @@ func (cs *CubeService) Unload(ctx context.Context, cubeName string) error {
 		return fmt.Errorf("Unload requires Data Admin privilege")
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Unload", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Unload", escapeODataKey(cubeName))
 	resp, err := cs.rest.Post(ctx, endpoint, nil)
 	if err != nil {
 		return err
@@ -606,7 +616,7 @@ func (cs *CubeService) Unload(ctx context.Context, cubeName string) error {
 
 // Lock locks a cube to prevent modifications by users
 func (cs *CubeService) Lock(ctx context.Context, cubeName string) error {
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Lock", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Lock", escapeODataKey(cubeName))
 	resp, err := cs.rest.Post(ctx, endpoint, nil)
 	if err != nil {
 		return err
@@ -618,7 +628,7 @@ func (cs *CubeService) Lock(ctx context.Context, cubeName string) error {
 
 // Unlock unlocks a cube to allow modifications by users
 func (cs *CubeService) Unlock(ctx context.Context, cubeName string) error {
-	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Unlock", url.PathEscape(cubeName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/tm1.Unlock", escapeODataKey(cubeName))
 	resp, err := cs.rest.Post(ctx, endpoint, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,59 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCubeServiceUpdateDrillthroughRules(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/Cubes('Sales')" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	rest, _ := NewRestService(Config{Address: "localhost", Port: 8882, SSL: false})
+	rest.SetBaseURL(server.URL)
+	cubes := NewCubeService(rest)
+
+	if err := cubes.UpdateDrillthroughRules(context.Background(), "Sales", "['Actual'] = S:'Detail';"); err != nil {
+		t.Fatalf("UpdateDrillthroughRules() failed: %v", err)
+	}
+	if payload["DrillthroughRules"] != "['Actual'] = S:'Detail';" {
+		t.Errorf("payload = %v", payload)
+	}
+
+	if err := cubes.UpdateDrillthroughRules(context.Background(), "Sales", ""); err != nil {
+		t.Fatalf("UpdateDrillthroughRules() failed: %v", err)
+	}
+	if rules, ok := payload["DrillthroughRules"]; !ok || rules != "" {
+		t.Errorf("clearing payload = %v", payload)
+	}
+}
+
+func TestCubeServiceExistsEscapesQuotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Cubes('O''Brien Sales')" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rest, _ := NewRestService(Config{Address: "localhost", Port: 8882, SSL: false})
+	rest.SetBaseURL(server.URL)
+
+	exists, err := NewCubeService(rest).Exists(context.Background(), "O'Brien Sales")
+	if err != nil || !exists {
+		t.Fatalf("Exists() = %v, %v", exists, err)
+	}
+}
@@ -2,6 +2,7 @@ package tm1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -12,9 +13,74 @@ import (
 	"github.com/go-gota/gota/series"
 )
 
+// DataFrameReadOption configures how a cellset is extracted into a DataFrame.
+type DataFrameReadOption func(*dataFrameReadOptions)
+
+type dataFrameReadOptions struct {
+	csv       bool
+	csvParams CSVParams
+}
+
+// DataFrameWriteOption configures how WriteDataFrame writes a DataFrame to a cube.
+type DataFrameWriteOption func(*WriteParams)
+
+// WithCSVExtraction extracts the cellset through the server CSV export (see StreamCellsetRows)
+// instead of the full JSON cellset. The resulting DataFrame has one column per hierarchy and a
+// Value column; cellProperties are ignored. params.SandboxName defaults to the sandbox passed to
+// the DataFrame function.
+func WithCSVExtraction(params CSVParams) DataFrameReadOption {
+	return func(o *dataFrameReadOptions) {
+		o.csv = true
+		o.csvParams = params
+	}
+}
+
+// WithElementResolvers makes WriteDataFrame resolve the coordinates of the given hierarchies to
+// principal names, so rows may use aliases or names differing in case and spaces.
+func WithElementResolvers(resolvers ...*ElementResolver) DataFrameWriteOption {
+	return func(p *WriteParams) {
+		p.Resolvers = append(p.Resolvers, resolvers...)
+	}
+}
+
+// WithStrictPicklists makes WriteDataFrame reject string values that are not on the picklist of
+// their cell. See WriteParams.StrictPicklists.
+func WithStrictPicklists() DataFrameWriteOption {
+	return func(p *WriteParams) {
+		p.StrictPicklists = true
+	}
+}
+
+// WithUpdateableCheck makes WriteDataFrame write only the cells the server reports as updateable.
+// See WriteParams.CheckUpdateable.
+func WithUpdateableCheck() DataFrameWriteOption {
+	return func(p *WriteParams) {
+		p.CheckUpdateable = true
+	}
+}
+
+func collectDataFrameReadOptions(sandboxName string, opts []DataFrameReadOption) dataFrameReadOptions {
+	var options dataFrameReadOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.csvParams.SandboxName == "" {
+		options.csvParams.SandboxName = sandboxName
+	}
+	return options
+}
+
 // ExecuteMDXDataFrame executes an MDX query and returns the result as a gota DataFrame.
 // dimensionNames is optional; when provided, it should match the coordinate order in the cellset.
-func (cs *CellService) ExecuteMDXDataFrame(ctx context.Context, mdx string, cellProperties []string, sandboxName string, dimensionNames []string) (dataframe.DataFrame, error) {
+func (cs *CellService) ExecuteMDXDataFrame(ctx context.Context, mdx string, cellProperties []string, sandboxName string, dimensionNames []string, opts ...DataFrameReadOption) (dataframe.DataFrame, error) {
+	if options := collectDataFrameReadOptions(sandboxName, opts); options.csv {
+		rows, err := cs.ExecuteMDXRows(ctx, mdx, options.csvParams)
+		if err != nil {
+			return dataframe.DataFrame{}, err
+		}
+		return RowsToDataFrame(rows, dimensionNames), nil
+	}
+
 	cellset, err := cs.ExecuteMDX(ctx, mdx, cellProperties, sandboxName)
 	if err != nil {
 		return dataframe.DataFrame{}, err
@@ -25,7 +91,15 @@ func (cs *CellService) ExecuteMDXDataFrame(ctx context.Context, mdx string, cell
 
 // ExecuteViewDataFrame executes a cube view and returns the result as a gota DataFrame.
 // dimensionNames is optional; when provided, it should match the coordinate order in the cellset.
-func (cs *CellService) ExecuteViewDataFrame(ctx context.Context, cubeName, viewName string, private bool, cellProperties []string, sandboxName string, dimensionNames []string) (dataframe.DataFrame, error) {
+func (cs *CellService) ExecuteViewDataFrame(ctx context.Context, cubeName, viewName string, private bool, cellProperties []string, sandboxName string, dimensionNames []string, opts ...DataFrameReadOption) (dataframe.DataFrame, error) {
+	if options := collectDataFrameReadOptions(sandboxName, opts); options.csv {
+		rows, err := cs.ExecuteViewRows(ctx, cubeName, viewName, private, options.csvParams)
+		if err != nil {
+			return dataframe.DataFrame{}, err
+		}
+		return RowsToDataFrame(rows, dimensionNames), nil
+	}
+
 	cellset, err := cs.ExecuteView(ctx, cubeName, viewName, private, cellProperties, sandboxName)
 	if err != nil {
 		return dataframe.DataFrame{}, err
@@ -34,10 +108,31 @@ func (cs *CellService) ExecuteViewDataFrame(ctx context.Context, cubeName, viewN
 	return CellsetToDataFrame(cellset, dimensionNames)
 }
 
+// ExecuteDrillthroughDataFrame executes a drillthrough script on a cell and returns the result as a gota DataFrame.
+// See ExecuteDrillthrough; dimensionNames is optional, as for ExecuteMDXDataFrame.
+func (cs *CellService) ExecuteDrillthroughDataFrame(ctx context.Context, cellsetID string, ordinal int, scriptName string, cellProperties []string, sandboxName string, dimensionNames []string, opts ...DataFrameReadOption) (dataframe.DataFrame, error) {
+	if options := collectDataFrameReadOptions(sandboxName, opts); options.csv {
+		rows, err := cs.ExecuteDrillthroughRows(ctx, cellsetID, ordinal, scriptName, options.csvParams)
+		if err != nil {
+			return dataframe.DataFrame{}, err
+		}
+		return RowsToDataFrame(rows, dimensionNames), nil
+	}
+
+	cellset, err := cs.ExecuteDrillthrough(ctx, cellsetID, ordinal, scriptName, cellProperties, sandboxName)
+	if err != nil {
+		return dataframe.DataFrame{}, err
+	}
+
+	return CellsetToDataFrame(cellset, dimensionNames)
+}
+
 // WriteDataFrame writes dataframe rows into a cube.
 // dimensions defines the column order for coordinates; if empty, all columns except valueColumn are used in the current dataframe order.
 // valueColumn defaults to "Value" when empty.
-func (cs *CellService) WriteDataFrame(ctx context.Context, cubeName string, df dataframe.DataFrame, dimensions []string, valueColumn string, sandboxName string) error {
+// With WithUpdateableCheck, a *RejectedCellsError is returned if cells were left out, joined with
+// the error of any chunk that failed to write.
+func (cs *CellService) WriteDataFrame(ctx context.Context, cubeName string, df dataframe.DataFrame, dimensions []string, valueColumn string, sandboxName string, opts ...DataFrameWriteOption) error {
 	if df.Nrow() == 0 {
 		return nil
 	}
@@ -86,7 +181,19 @@ func (cs *CellService) WriteDataFrame(ctx context.Context, cubeName string, df d
 		coords = append(coords, rowCoords)
 	}
 
-	return cs.WriteValuesByCoords(ctx, cubeName, coords, values, dimensions, sandboxName)
+	var params WriteParams
+	for _, opt := range opts {
+		opt(&params)
+	}
+	report, err := cs.WriteValuesByCoordsChunked(ctx, cubeName, coords, values, dimensions, sandboxName, params)
+	if err != nil {
+		return err
+	}
+	var rejected error
+	if len(report.Rejected) > 0 {
+		rejected = &RejectedCellsError{Cells: report.Rejected}
+	}
+	return errors.Join(report.Err(), rejected)
 }
 
 // CellsetToDataFrame converts a cellset into a gota DataFrame.
@@ -167,6 +274,71 @@ func CellsetToDataFrame(cellset *Cellset, dimensionNames []string) (dataframe.Da
 	return dataframe.New(seriesList...), nil
 }
 
+// RowsToDataFrame converts rows produced by ExtractCellsetRows into a gota DataFrame.
+// The first row is the header; dimensionNames optionally overrides the coordinate column names.
+// Values that parse as numbers become a float column, otherwise the Value column holds strings.
+func RowsToDataFrame(rows [][]string, dimensionNames []string) dataframe.DataFrame {
+	if len(rows) == 0 {
+		return dataframe.New()
+	}
+
+	header := rows[0]
+	coordLen := len(header) - 1
+	if coordLen < 0 {
+		return dataframe.New()
+	}
+
+	names := make([]string, coordLen)
+	copy(names, header[:coordLen])
+	for i := range names {
+		if i < len(dimensionNames) && strings.TrimSpace(dimensionNames[i]) != "" {
+			names[i] = dimensionNames[i]
+		}
+	}
+
+	coordCols := make([][]string, coordLen)
+	for i := range coordCols {
+		coordCols[i] = make([]string, 0, len(rows)-1)
+	}
+	values := make([]interface{}, 0, len(rows)-1)
+	numeric := true
+	for _, row := range rows[1:] {
+		for i := 0; i < coordLen; i++ {
+			value := ""
+			if i < len(row) {
+				value = row[i]
+			}
+			coordCols[i] = append(coordCols[i], value)
+		}
+		value := ""
+		if coordLen < len(row) {
+			value = row[coordLen]
+		}
+		values = append(values, value)
+		if _, err := strconv.ParseFloat(value, 64); err != nil && value != "" {
+			numeric = false
+		}
+	}
+
+	if numeric {
+		for i, value := range values {
+			if value == "" {
+				values[i] = nil
+				continue
+			}
+			values[i], _ = strconv.ParseFloat(value.(string), 64)
+		}
+	}
+
+	seriesList := make([]series.Series, 0, coordLen+1)
+	for i, name := range names {
+		seriesList = append(seriesList, series.New(coordCols[i], series.String, name))
+	}
+	seriesList = append(seriesList, buildSeriesFromInterfaces("Value", values))
+
+	return dataframe.New(seriesList...)
+}
+
 func splitCoordKey(coordKey string) []string {
 	if coordKey == "" {
 		return nil
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/url"
 	"strings"
 
 	"github.com/andreyea/tm1go/pkg/models"
@@ -27,8 +26,15 @@ func NewDimensionService(rest *RestService) *DimensionService {
 	}
 }
 
-// Create creates a new dimension in TM1
+// Create creates a new dimension in TM1. With Config.ValidateModels the dimension is
+// checked with models.Dimension.Validate before anything is sent to the server.
 func (ds *DimensionService) Create(ctx context.Context, dimension *models.Dimension) error {
+	if ds.rest.validateModels {
+		if err := dimension.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Check if dimension already exists
 	exists, err := ds.Exists(ctx, dimension.Name)
 	if err != nil {
@@ -66,7 +72,7 @@ func (ds *DimensionService) Create(ctx context.Context, dimension *models.Dimens
 // Get retrieves a dimension by name
 func (ds *DimensionService) Get(ctx context.Context, dimensionName string) (*models.Dimension, error) {
 	url := fmt.Sprintf("/Dimensions('%s')?$expand=Hierarchies($expand=*)",
-		url.PathEscape(dimensionName))
+		escapeODataKey(dimensionName))
 
 	resp, err := ds.rest.Get(ctx, url)
 	if err != nil {
@@ -87,8 +93,14 @@ func (ds *DimensionService) Get(ctx context.Context, dimensionName string) (*mod
 	return dimension, nil
 }
 
-// Update updates an existing dimension
+// Update updates an existing dimension, validating it first like Create
 func (ds *DimensionService) Update(ctx context.Context, dimension *models.Dimension, keepExistingAttributes bool) error {
+	if ds.rest.validateModels {
+		if err := dimension.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Get list of hierarchies to be removed
 	existingHierarchies, err := ds.hierarchies.GetAllNames(ctx, dimension.Name)
 	if err != nil {
@@ -177,7 +189,7 @@ func (ds *DimensionService) UpdateOrCreate(ctx context.Context, dimension *model
 
 // Delete deletes a dimension
 func (ds *DimensionService) Delete(ctx context.Context, dimensionName string) error {
-	url := fmt.Sprintf("/Dimensions('%s')", url.PathEscape(dimensionName))
+	url := fmt.Sprintf("/Dimensions('%s')", escapeODataKey(dimensionName))
 
 	resp, err := ds.rest.Delete(ctx, url)
 	if err != nil {
@@ -191,7 +203,7 @@ func (ds *DimensionService) Delete(ctx context.Context, dimensionName string) er
 
 // Exists checks if a dimension exists
 func (ds *DimensionService) Exists(ctx context.Context, dimensionName string) (bool, error) {
-	url := fmt.Sprintf("/Dimensions('%s')", url.PathEscape(dimensionName))
+	url := fmt.Sprintf("/Dimensions('%s')", escapeODataKey(dimensionName))
 
 	resp, err := ds.rest.Get(ctx, url)
 	if err != nil {
@@ -2,6 +2,7 @@ package tm1
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -41,6 +42,40 @@ func TestDimensionServiceCreate(t *testing.T) {
 	}
 }
 
+func TestDimensionServiceCreateWithValidation(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false, ValidateModels: true}
+	rest, _ := NewRestService(cfg)
+	rest.SetBaseURL(server.URL)
+
+	hierarchy := models.NewHierarchy("Region", "Region")
+	hierarchy.AddElement(models.Element{Name: "Total", Type: models.ElementTypeConsolidated})
+	hierarchy.AddElement(models.Element{Name: "North", Type: models.ElementTypeNumeric})
+	hierarchy.AddEdge("Total", "North", 1)
+	hierarchy.AddEdge("Total", "South", 1)
+	dim := models.NewDimension("Region")
+	dim.AddHierarchy(*hierarchy)
+
+	err := NewDimensionService(rest).Create(context.Background(), dim)
+	var validationErr *models.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Create() error = %v, want ValidationError", err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("invalid dimension was sent to the server: %v", requests)
+	}
+	want := "hierarchy 'Region': edge 'Total' -> 'South': component element 'South' does not exist"
+	if len(validationErr.Problems) != 1 || validationErr.Problems[0].String() != want {
+		t.Errorf("problems = %v, want %s", validationErr.Problems, want)
+	}
+}
+
 func TestDimensionServiceExists(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/Dimensions('ExistingDim')" {
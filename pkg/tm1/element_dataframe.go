@@ -0,0 +1,173 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+// ElementsDataFrameParams selects the columns of GetElementsDataFrame.
+type ElementsDataFrameParams struct {
+	Attributes         []string // Attribute columns, in this order
+	AllAttributes      bool     // Add a column for every attribute of the hierarchy, ordered by name
+	AncestorLevels     int      // Number of Ancestor1..AncestorN columns, following the first parent upwards
+	SkipConsolidations bool     // Only return leaf elements
+}
+
+// GetElementsDataFrame returns a hierarchy as a table with one row per element, ordered by index.
+// The columns are the element name (named after the hierarchy), Type, Level and Index, a Parent<n> and
+// Weight<n> pair for each parent, the ancestor columns and the selected attributes. Parents are ordered
+// by index, and missing values are empty. Elements and edges are fetched with two requests.
+func (es *ElementService) GetElementsDataFrame(ctx context.Context, dimensionName, hierarchyName string, params ElementsDataFrameParams) (dataframe.DataFrame, error) {
+	if hierarchyName == "" {
+		hierarchyName = dimensionName
+	}
+
+	sel := "Name,Type,Level,Index"
+	if params.AllAttributes || len(params.Attributes) > 0 {
+		sel += ",Attributes"
+	}
+	endpoint := fmt.Sprintf(
+		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=%s",
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		sel,
+	)
+
+	resp, err := es.rest.Get(ctx, endpoint)
+	if err != nil {
+		return dataframe.DataFrame{}, fmt.Errorf("get elements: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Value []struct {
+			Name       string                 `json:"Name"`
+			Type       string                 `json:"Type"`
+			Level      int                    `json:"Level"`
+			Index      int                    `json:"Index"`
+			Attributes map[string]interface{} `json:"Attributes"`
+		} `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return dataframe.DataFrame{}, fmt.Errorf("decode elements: %w", err)
+	}
+
+	edges, err := es.GetEdges(ctx, dimensionName, hierarchyName)
+	if err != nil {
+		return dataframe.DataFrame{}, err
+	}
+
+	elements := result.Value
+	sort.SliceStable(elements, func(i, j int) bool { return elements[i].Index < elements[j].Index })
+	index := make(map[string]int, len(elements))
+	for _, element := range elements {
+		index[normalizeCaseSpace(element.Name)] = element.Index
+	}
+
+	type parentEdge struct {
+		name   string
+		weight float64
+	}
+	parents := make(map[string][]parentEdge)
+	for key, weight := range edges {
+		child := normalizeCaseSpace(key[1])
+		parents[child] = append(parents[child], parentEdge{name: key[0], weight: weight})
+	}
+	maxParents := 0
+	for child := range parents {
+		list := parents[child]
+		sort.Slice(list, func(i, j int) bool {
+			return index[normalizeCaseSpace(list[i].name)] < index[normalizeCaseSpace(list[j].name)]
+		})
+		if len(list) > maxParents {
+			maxParents = len(list)
+		}
+	}
+
+	attributes := params.Attributes
+	if params.AllAttributes {
+		seen := make(map[string]bool)
+		attributes = nil
+		for _, element := range elements {
+			for name := range element.Attributes {
+				if !seen[name] {
+					seen[name] = true
+					attributes = append(attributes, name)
+				}
+			}
+		}
+		sort.Strings(attributes)
+	}
+
+	var names, types []string
+	var levels, indexes []int
+	parentCols := make([][]string, maxParents)
+	weightCols := make([][]interface{}, maxParents)
+	ancestorCols := make([][]string, params.AncestorLevels)
+	attributeCols := make([][]interface{}, len(attributes))
+
+	for _, element := range elements {
+		if params.SkipConsolidations && element.Type == "Consolidated" {
+			continue
+		}
+		names = append(names, element.Name)
+		types = append(types, element.Type)
+		levels = append(levels, element.Level)
+		indexes = append(indexes, element.Index)
+
+		elementParents := parents[normalizeCaseSpace(element.Name)]
+		for i := 0; i < maxParents; i++ {
+			if i < len(elementParents) {
+				parentCols[i] = append(parentCols[i], elementParents[i].name)
+				weightCols[i] = append(weightCols[i], elementParents[i].weight)
+			} else {
+				parentCols[i] = append(parentCols[i], "")
+				weightCols[i] = append(weightCols[i], nil)
+			}
+		}
+
+		ancestor := element.Name
+		for i := 0; i < params.AncestorLevels; i++ {
+			if list := parents[normalizeCaseSpace(ancestor)]; ancestor != "" && len(list) > 0 {
+				ancestor = list[0].name
+			} else {
+				ancestor = ""
+			}
+			ancestorCols[i] = append(ancestorCols[i], ancestor)
+		}
+
+		byKey := make(map[string]interface{}, len(element.Attributes))
+		for name, value := range element.Attributes {
+			byKey[normalizeCaseSpace(name)] = value
+		}
+		for i, name := range attributes {
+			attributeCols[i] = append(attributeCols[i], byKey[normalizeCaseSpace(name)])
+		}
+	}
+
+	columns := []series.Series{
+		series.New(names, series.String, hierarchyName),
+		series.New(types, series.String, "Type"),
+		series.New(levels, series.Int, "Level"),
+		series.New(indexes, series.Int, "Index"),
+	}
+	for i := 0; i < maxParents; i++ {
+		suffix := strconv.Itoa(i + 1)
+		columns = append(columns, series.New(parentCols[i], series.String, "Parent"+suffix))
+		columns = append(columns, toFloatSeries("Weight"+suffix, weightCols[i]))
+	}
+	for i := 0; i < params.AncestorLevels; i++ {
+		columns = append(columns, series.New(ancestorCols[i], series.String, "Ancestor"+strconv.Itoa(i+1)))
+	}
+	for i, name := range attributes {
+		columns = append(columns, buildSeriesFromInterfaces(name, attributeCols[i]))
+	}
+
+	return dataframe.New(columns...), nil
+}
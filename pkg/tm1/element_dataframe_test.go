@@ -0,0 +1,81 @@
+package tm1
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestElementServiceGetElementsDataFrame(t *testing.T) {
+	var queries []string
+	service, server := setupTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/Elements"):
+			w.Write([]byte(`{"value":[
+				{"Name":"Oslo","Type":"Numeric","Level":0,"Index":3,"Attributes":{"Code":"OSL","Population":700000}},
+				{"Name":"World","Type":"Consolidated","Level":2,"Index":1,"Attributes":{"Code":"W","Population":null}},
+				{"Name":"Norway","Type":"Consolidated","Level":1,"Index":2,"Attributes":{"Code":"NO","Population":null}},
+				{"Name":"Nordics","Type":"Consolidated","Level":1,"Index":4,"Attributes":{"Code":"","Population":null}}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, "/Edges"):
+			w.Write([]byte(`{"value":[
+				{"ParentName":"World","ComponentName":"Norway","Weight":1},
+				{"ParentName":"Nordics","ComponentName":"Oslo","Weight":0.5},
+				{"ParentName":"Norway","ComponentName":"Oslo","Weight":1}
+			]}`))
+		}
+	}))
+	defer server.Close()
+
+	df, err := service.GetElementsDataFrame(context.Background(), "City", "", ElementsDataFrameParams{
+		Attributes:     []string{"population", "Code"},
+		AncestorLevels: 2,
+	})
+	if err != nil {
+		t.Fatalf("GetElementsDataFrame() failed: %v", err)
+	}
+
+	wantQueries := []string{
+		"/Dimensions('City')/Hierarchies('City')/Elements?$select=Name,Type,Level,Index,Attributes",
+		"/Dimensions('City')/Hierarchies('City')/Edges?$select=ParentName,ComponentName,Weight",
+	}
+	if strings.Join(queries, "\n") != strings.Join(wantQueries, "\n") {
+		t.Errorf("queries = %v", queries)
+	}
+
+	wantNames := "City,Type,Level,Index,Parent1,Weight1,Parent2,Weight2,Ancestor1,Ancestor2,population,Code"
+	if got := strings.Join(df.Names(), ","); got != wantNames {
+		t.Fatalf("columns = %s, want %s", got, wantNames)
+	}
+	if got := strings.Join(df.Col("City").Records(), ","); got != "World,Norway,Oslo,Nordics" {
+		t.Errorf("element order = %s", got)
+	}
+
+	oslo := 2
+	if got := df.Col("Parent1").Elem(oslo).String() + "," + df.Col("Parent2").Elem(oslo).String(); got != "Norway,Nordics" {
+		t.Errorf("Oslo parents = %s", got)
+	}
+	if got := df.Col("Weight2").Elem(oslo).Float(); got != 0.5 {
+		t.Errorf("Oslo Weight2 = %v", got)
+	}
+	if got := df.Col("Ancestor2").Elem(oslo).String(); got != "World" {
+		t.Errorf("Oslo Ancestor2 = %s", got)
+	}
+	if got := df.Col("population").Elem(oslo).Float(); got != 700000 {
+		t.Errorf("Oslo population = %v", got)
+	}
+	if got := df.Col("Weight1").Elem(0).Float(); !math.IsNaN(got) {
+		t.Errorf("World Weight1 = %v, want NaN", got)
+	}
+
+	leaves, err := service.GetElementsDataFrame(context.Background(), "City", "City", ElementsDataFrameParams{AllAttributes: true, SkipConsolidations: true})
+	if err != nil {
+		t.Fatalf("GetElementsDataFrame() failed: %v", err)
+	}
+	if leaves.Nrow() != 1 || strings.Join(leaves.Names()[len(leaves.Names())-2:], ",") != "Code,Population" {
+		t.Errorf("leaves = %v", leaves)
+	}
+}
@@ -0,0 +1,151 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+// ElementResolver maps user-supplied element names and aliases of one hierarchy to principal names,
+// the way TM1 does: case- and space-insensitively, with principal names taking precedence over aliases.
+// A resolver is loaded once and can be shared between goroutines, as it is read-only.
+type ElementResolver struct {
+	DimensionName string
+	HierarchyName string
+	names         map[string]string // Normalized name or alias -> principal name
+}
+
+// NewElementResolver builds a resolver from principal element names and an alias -> principal name map.
+// Aliases that are not unique or point to unknown elements are ignored.
+func NewElementResolver(dimensionName, hierarchyName string, elementNames []string, aliases map[string]string) *ElementResolver {
+	if hierarchyName == "" {
+		hierarchyName = dimensionName
+	}
+	principals := make(map[string]string, len(elementNames)+len(aliases))
+	for _, name := range elementNames {
+		principals[normalizeCaseSpace(name)] = name
+	}
+
+	aliasTargets := make(map[string]string, len(aliases))
+	ambiguous := make(map[string]bool)
+	for alias, element := range aliases {
+		key := normalizeCaseSpace(alias)
+		principal, ok := principals[normalizeCaseSpace(element)]
+		if !ok || key == "" {
+			continue
+		}
+		if _, isName := principals[key]; isName {
+			continue
+		}
+		if existing, seen := aliasTargets[key]; seen && existing != principal {
+			ambiguous[key] = true
+		}
+		aliasTargets[key] = principal
+	}
+
+	for key, principal := range aliasTargets {
+		if !ambiguous[key] {
+			principals[key] = principal
+		}
+	}
+	return &ElementResolver{DimensionName: dimensionName, HierarchyName: hierarchyName, names: principals}
+}
+
+// GetElementResolver loads the element names and alias attribute values of a hierarchy into a resolver.
+func (es *ElementService) GetElementResolver(ctx context.Context, dimensionName, hierarchyName string) (*ElementResolver, error) {
+	if hierarchyName == "" {
+		hierarchyName = dimensionName
+	}
+
+	attributes, err := es.GetElementAttributes(ctx, dimensionName, hierarchyName)
+	if err != nil {
+		return nil, err
+	}
+	var aliasNames []string
+	for _, attribute := range attributes {
+		if attribute.AttributeType == models.AttributeTypeAlias {
+			aliasNames = append(aliasNames, attribute.Name)
+		}
+	}
+
+	if len(aliasNames) == 0 {
+		names, err := es.GetElementNames(ctx, dimensionName, hierarchyName)
+		if err != nil {
+			return nil, err
+		}
+		return NewElementResolver(dimensionName, hierarchyName, names, nil), nil
+	}
+
+	values, err := es.GetAttributeValues(ctx, dimensionName, hierarchyName, aliasNames, nil)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(values))
+	aliases := make(map[string]string)
+	for element, elementValues := range values {
+		names = append(names, element)
+		for _, value := range elementValues {
+			if alias, ok := value.(string); ok && alias != "" {
+				aliases[alias] = element
+			}
+		}
+	}
+	return NewElementResolver(dimensionName, hierarchyName, names, aliases), nil
+}
+
+// Lookup returns the principal name of an element name or alias, and whether it was found.
+func (r *ElementResolver) Lookup(name string) (string, bool) {
+	principal, ok := r.names[normalizeCaseSpace(name)]
+	return principal, ok
+}
+
+// Resolve returns the principal name of an element name or alias, or an error if the hierarchy has
+// no such element.
+func (r *ElementResolver) Resolve(name string) (string, error) {
+	if principal, ok := r.Lookup(name); ok {
+		return principal, nil
+	}
+	return "", fmt.Errorf("element '%s' not found in hierarchy '%s:%s'", name, r.DimensionName, r.HierarchyName)
+}
+
+// ResolveAll resolves a list of names, failing on the first unknown name.
+func (r *ElementResolver) ResolveAll(names []string) ([]string, error) {
+	resolved := make([]string, len(names))
+	for i, name := range names {
+		principal, err := r.Resolve(name)
+		if err != nil {
+			return nil, err
+		}
+		resolved[i] = principal
+	}
+	return resolved, nil
+}
+
+// elementResolvers indexes resolvers by dimension and hierarchy for coordinate resolution.
+type elementResolvers map[string]*ElementResolver
+
+func newElementResolvers(resolvers []*ElementResolver) elementResolvers {
+	if len(resolvers) == 0 {
+		return nil
+	}
+	index := make(elementResolvers, len(resolvers))
+	for _, r := range resolvers {
+		index[resolverKey(r.DimensionName, r.HierarchyName)] = r
+	}
+	return index
+}
+
+// resolve returns the principal name of element in the given hierarchy. Elements of hierarchies
+// without a resolver are returned unchanged.
+func (rs elementResolvers) resolve(dimensionName, hierarchyName, element string) (string, error) {
+	r, ok := rs[resolverKey(dimensionName, hierarchyName)]
+	if !ok {
+		return element, nil
+	}
+	return r.Resolve(element)
+}
+
+func resolverKey(dimensionName, hierarchyName string) string {
+	return normalizeCaseSpace(dimensionName) + ":" + normalizeCaseSpace(hierarchyName)
+}
@@ -0,0 +1,90 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestElementResolver(t *testing.T) {
+	resolver := NewElementResolver("Period", "", []string{"Jan 2024", "Feb 2024", "Q1"}, map[string]string{
+		"January":  "jan2024",
+		"Jan":      "Jan 2024",
+		"q1":       "Feb 2024", // Collides with a principal name
+		"Month":    "Jan 2024",
+		"month":    "Feb 2024", // Ambiguous
+		"Missing":  "Mar 2024",
+		"February": "Feb 2024",
+	})
+
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{"Jan 2024", "Jan 2024", true},
+		{"jan2024", "Jan 2024", true},
+		{" JAN 2024 ", "Jan 2024", true},
+		{"january", "Jan 2024", true},
+		{"Feb ruary", "Feb 2024", true},
+		{"Q1", "Q1", true},
+		{"Month", "", false},
+		{"Missing", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := resolver.Lookup(tt.name)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Lookup(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if _, err := resolver.ResolveAll([]string{"jan", "Mar 2024"}); err == nil || !strings.Contains(err.Error(), "'Mar 2024' not found in hierarchy 'Period:Period'") {
+		t.Errorf("ResolveAll() error = %v", err)
+	}
+}
+
+func TestCellService_WriteValuesWithResolvers(t *testing.T) {
+	var updates []map[string]interface{}
+	elements, server := setupTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ElementAttributes"):
+			w.Write([]byte(`{"value":[{"Name":"Short","Type":"Alias"},{"Name":"Days","Type":"Numeric"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/Elements"):
+			w.Write([]byte(`{"value":[
+				{"Name":"Jan 2024","Attributes":{"Short":"Jan","Days":31}},
+				{"Name":"Feb 2024","Attributes":{"Short":"Feb","Days":29}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/tm1.Update"):
+			json.NewDecoder(r.Body).Decode(&updates)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	resolver, err := elements.GetElementResolver(context.Background(), "Period", "")
+	if err != nil {
+		t.Fatalf("GetElementResolver() failed: %v", err)
+	}
+
+	cells := NewCellService(elements.rest)
+	coords := [][]string{{"jan2024", "Sales"}, {"FEB", "Sales"}}
+	params := WriteParams{Resolvers: []*ElementResolver{resolver}}
+	if _, err := cells.WriteValuesByCoordsChunked(context.Background(), "Plan", coords, []interface{}{1, 2}, []string{"Period", "Measure"}, "", params); err != nil {
+		t.Fatalf("WriteValuesByCoordsChunked() failed: %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("updates = %v", updates)
+	}
+	binds := updates[1]["Cells"].([]interface{})[0].(map[string]interface{})["Tuple@odata.bind"].([]interface{})
+	if binds[0] != "Dimensions('Period')/Hierarchies('Period')/Elements('Feb%202024')" || binds[1] != "Dimensions('Measure')/Hierarchies('Measure')/Elements('Sales')" {
+		t.Errorf("bindings = %v", binds)
+	}
+
+	_, err = cells.WriteValuesByCoordsChunked(context.Background(), "Plan", [][]string{{"Mar", "Sales"}}, []interface{}{3}, []string{"Period", "Measure"}, "", params)
+	if err == nil || !strings.Contains(err.Error(), "coordinate at index 0: element 'Mar' not found") {
+		t.Errorf("unknown element error = %v", err)
+	}
+}
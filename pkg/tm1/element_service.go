@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -38,9 +39,9 @@ func NewElementService(rest *RestService) *ElementService {
 func (es *ElementService) Get(ctx context.Context, dimensionName, hierarchyName, elementName string) (*models.Element, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements('%s')?$expand=*",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(elementName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(elementName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -61,8 +62,8 @@ func (es *ElementService) Get(ctx context.Context, dimensionName, hierarchyName,
 func (es *ElementService) Create(ctx context.Context, dimensionName, hierarchyName string, element models.Element) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	body, err := json.Marshal(element)
@@ -83,9 +84,9 @@ func (es *ElementService) Create(ctx context.Context, dimensionName, hierarchyNa
 func (es *ElementService) Update(ctx context.Context, dimensionName, hierarchyName string, element models.Element) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(element.Name),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(element.Name),
 	)
 
 	body, err := json.Marshal(element)
@@ -119,9 +120,9 @@ func (es *ElementService) UpdateOrCreate(ctx context.Context, dimensionName, hie
 func (es *ElementService) Exists(ctx context.Context, dimensionName, hierarchyName, elementName string) (bool, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(elementName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(elementName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -140,8 +141,8 @@ func (es *ElementService) Exists(ctx context.Context, dimensionName, hierarchyNa
 func (es *ElementService) HierarchyExists(ctx context.Context, dimensionName, hierarchyName string) (bool, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -160,7 +161,7 @@ func (es *ElementService) HierarchyExists(ctx context.Context, dimensionName, hi
 func (es *ElementService) AttributeCubeExists(ctx context.Context, dimensionName string) (bool, error) {
 	endpoint := fmt.Sprintf(
 		"/Cubes('%s')",
-		url.PathEscape("}ElementAttributes_"+dimensionName),
+		escapeODataKey("}ElementAttributes_"+dimensionName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -179,9 +180,9 @@ func (es *ElementService) AttributeCubeExists(ctx context.Context, dimensionName
 func (es *ElementService) Delete(ctx context.Context, dimensionName, hierarchyName, elementName string) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(elementName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(elementName),
 	)
 
 	resp, err := es.rest.Delete(ctx, endpoint)
@@ -197,8 +198,8 @@ func (es *ElementService) Delete(ctx context.Context, dimensionName, hierarchyNa
 func (es *ElementService) GetElements(ctx context.Context, dimensionName, hierarchyName string) ([]models.Element, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name,Type",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -222,8 +223,8 @@ func (es *ElementService) GetElements(ctx context.Context, dimensionName, hierar
 func (es *ElementService) GetElementNames(ctx context.Context, dimensionName, hierarchyName string) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -254,8 +255,8 @@ func (es *ElementService) GetElementNames(ctx context.Context, dimensionName, hi
 func (es *ElementService) GetLeafElements(ctx context.Context, dimensionName, hierarchyName string) ([]models.Element, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$expand=*&$filter=Type+ne+3",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -279,8 +280,8 @@ func (es *ElementService) GetLeafElements(ctx context.Context, dimensionName, hi
 func (es *ElementService) GetLeafElementNames(ctx context.Context, dimensionName, hierarchyName string) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name&$filter=Type+ne+3",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -311,8 +312,8 @@ func (es *ElementService) GetLeafElementNames(ctx context.Context, dimensionName
 func (es *ElementService) GetConsolidatedElements(ctx context.Context, dimensionName, hierarchyName string) ([]models.Element, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$expand=*&$filter=Type+eq+3",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -336,8 +337,8 @@ func (es *ElementService) GetConsolidatedElements(ctx context.Context, dimension
 func (es *ElementService) GetConsolidatedElementNames(ctx context.Context, dimensionName, hierarchyName string) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name&$filter=Type+eq+3",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -368,8 +369,8 @@ func (es *ElementService) GetConsolidatedElementNames(ctx context.Context, dimen
 func (es *ElementService) GetNumericElements(ctx context.Context, dimensionName, hierarchyName string) ([]models.Element, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$expand=*&$filter=Type+eq+1",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -393,8 +394,8 @@ func (es *ElementService) GetNumericElements(ctx context.Context, dimensionName,
 func (es *ElementService) GetNumericElementNames(ctx context.Context, dimensionName, hierarchyName string) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name&$filter=Type+eq+1",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -425,8 +426,8 @@ func (es *ElementService) GetNumericElementNames(ctx context.Context, dimensionN
 func (es *ElementService) GetStringElements(ctx context.Context, dimensionName, hierarchyName string) ([]models.Element, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$expand=*&$filter=Type+eq+2",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -450,8 +451,8 @@ func (es *ElementService) GetStringElements(ctx context.Context, dimensionName,
 func (es *ElementService) GetStringElementNames(ctx context.Context, dimensionName, hierarchyName string) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name&$filter=Type+eq+2",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -482,8 +483,8 @@ func (es *ElementService) GetStringElementNames(ctx context.Context, dimensionNa
 func (es *ElementService) GetNumberOfElements(ctx context.Context, dimensionName, hierarchyName string) (int, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements/$count",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -509,8 +510,8 @@ func (es *ElementService) GetNumberOfElements(ctx context.Context, dimensionName
 func (es *ElementService) GetNumberOfConsolidatedElements(ctx context.Context, dimensionName, hierarchyName string) (int, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements/$count?$filter=Type+eq+3",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -536,8 +537,8 @@ func (es *ElementService) GetNumberOfConsolidatedElements(ctx context.Context, d
 func (es *ElementService) GetNumberOfLeafElements(ctx context.Context, dimensionName, hierarchyName string) (int, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements/$count?$filter=Type+ne+3",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -563,8 +564,8 @@ func (es *ElementService) GetNumberOfLeafElements(ctx context.Context, dimension
 func (es *ElementService) GetNumberOfNumericElements(ctx context.Context, dimensionName, hierarchyName string) (int, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements/$count?$filter=Type+eq+1",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -590,8 +591,8 @@ func (es *ElementService) GetNumberOfNumericElements(ctx context.Context, dimens
 func (es *ElementService) GetNumberOfStringElements(ctx context.Context, dimensionName, hierarchyName string) (int, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements/$count?$filter=Type+eq+2",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -617,8 +618,8 @@ func (es *ElementService) GetNumberOfStringElements(ctx context.Context, dimensi
 func (es *ElementService) GetEdges(ctx context.Context, dimensionName, hierarchyName string) (map[[2]string]float64, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Edges?$select=ParentName,ComponentName,Weight",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -648,8 +649,8 @@ func (es *ElementService) GetEdges(ctx context.Context, dimensionName, hierarchy
 func (es *ElementService) GetElementAttributes(ctx context.Context, dimensionName, hierarchyName string) ([]models.ElementAttribute, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/ElementAttributes",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -673,8 +674,8 @@ func (es *ElementService) GetElementAttributes(ctx context.Context, dimensionNam
 func (es *ElementService) GetElementAttributeNames(ctx context.Context, dimensionName, hierarchyName string) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/ElementAttributes?$select=Name",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -705,8 +706,8 @@ func (es *ElementService) GetElementAttributeNames(ctx context.Context, dimensio
 func (es *ElementService) CreateElementAttribute(ctx context.Context, dimensionName, hierarchyName string, attribute models.ElementAttribute) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/ElementAttributes",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	body, err := json.Marshal(attribute)
@@ -727,9 +728,9 @@ func (es *ElementService) CreateElementAttribute(ctx context.Context, dimensionN
 func (es *ElementService) DeleteElementAttribute(ctx context.Context, dimensionName, hierarchyName, attributeName string) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('}ElementAttributes_%s')/Hierarchies('}ElementAttributes_%s')/Elements('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(attributeName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(attributeName),
 	)
 
 	resp, err := es.rest.Delete(ctx, endpoint)
@@ -821,8 +822,8 @@ func (es *ElementService) AddEdges(ctx context.Context, dimensionName, hierarchy
 
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Edges",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	edgeList := make([]map[string]interface{}, 0, len(edges))
@@ -852,11 +853,11 @@ func (es *ElementService) AddEdges(ctx context.Context, dimensionName, hierarchy
 func (es *ElementService) RemoveEdge(ctx context.Context, dimensionName, hierarchyName, parent, component string) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements('%s')/Edges(ParentName='%s',ComponentName='%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(parent),
-		url.PathEscape(parent),
-		url.PathEscape(component),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(parent),
+		escapeODataKey(parent),
+		escapeODataKey(component),
 	)
 
 	resp, err := es.rest.Delete(ctx, endpoint)
@@ -872,8 +873,8 @@ func (es *ElementService) RemoveEdge(ctx context.Context, dimensionName, hierarc
 func (es *ElementService) AddElements(ctx context.Context, dimensionName, hierarchyName string, elements []models.Element) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	body, err := json.Marshal(elements)
@@ -894,8 +895,8 @@ func (es *ElementService) AddElements(ctx context.Context, dimensionName, hierar
 func (es *ElementService) AddElementAttributes(ctx context.Context, dimensionName, hierarchyName string, attributes []models.ElementAttribute) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/ElementAttributes",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	body, err := json.Marshal(attributes)
@@ -916,8 +917,8 @@ func (es *ElementService) AddElementAttributes(ctx context.Context, dimensionNam
 func (es *ElementService) GetElementsByLevel(ctx context.Context, dimensionName, hierarchyName string, level int) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name&$filter=Level+eq+%d",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 		level,
 	)
 
@@ -954,8 +955,8 @@ func (es *ElementService) GetElementsFilteredByWildcard(ctx context.Context, dim
 
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name&$filter=%s",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 		url.QueryEscape(filter),
 	)
 
@@ -998,9 +999,9 @@ func (es *ElementService) getMembersUnderConsolidation(ctx context.Context, dime
 	// Build URL with recursive expansion
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements('%s')?$select=Name,Type&$expand=Components(",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(consolidation),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(consolidation),
 	)
 
 	for i := 0; i < depth-1; i++ {
@@ -1054,9 +1055,9 @@ func (es *ElementService) GetEdgesUnderConsolidation(ctx context.Context, dimens
 	// Build URL with recursive expansion
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements('%s')?$select=Edges&$expand=Edges($expand=Component(",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(consolidation),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(consolidation),
 	)
 
 	for i := 0; i < maxDepth-1; i++ {
@@ -1110,9 +1111,9 @@ func (es *ElementService) GetEdgesUnderConsolidation(ctx context.Context, dimens
 func (es *ElementService) GetParents(ctx context.Context, dimensionName, hierarchyName, elementName string) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements('%s')/Parents?$select=Name",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
-		url.PathEscape(elementName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+		escapeODataKey(elementName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -1143,8 +1144,8 @@ func (es *ElementService) GetParents(ctx context.Context, dimensionName, hierarc
 func (es *ElementService) GetParentsOfAllElements(ctx context.Context, dimensionName, hierarchyName string) (map[string][]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name&$expand=Parents($select=Name)",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -1198,8 +1199,8 @@ func (es *ElementService) ElementIsParent(ctx context.Context, dimensionName, hi
 func (es *ElementService) GetLevelNames(ctx context.Context, dimensionName, hierarchyName string, descending bool) ([]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Levels?$select=Name",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -1237,8 +1238,8 @@ func (es *ElementService) GetLevelNames(ctx context.Context, dimensionName, hier
 func (es *ElementService) GetLevelsCount(ctx context.Context, dimensionName, hierarchyName string) (int, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Levels/$count",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := es.rest.Get(ctx, endpoint)
@@ -1264,8 +1265,8 @@ func (es *ElementService) GetLevelsCount(ctx context.Context, dimensionName, hie
 func (es *ElementService) GetElementTypes(ctx context.Context, dimensionName, hierarchyName string, skipConsolidations bool) (map[string]string, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name,Type",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	if skipConsolidations {
@@ -1305,3 +1306,252 @@ func (es *ElementService) GetElementPrincipalName(ctx context.Context, dimension
 	}
 	return element.Name, nil
 }
+
+// GetAttributeValues retrieves element attribute values as map[element]map[attribute]value.
+// attributeNames and elementNames restrict the result; when empty, all attributes or elements are returned.
+// Names are matched case- and space-insensitively; the result uses element names as stored on the server
+// and attribute names as requested.
+func (es *ElementService) GetAttributeValues(ctx context.Context, dimensionName, hierarchyName string, attributeNames, elementNames []string) (map[string]map[string]interface{}, error) {
+	if hierarchyName == "" {
+		hierarchyName = dimensionName
+	}
+
+	endpoint := fmt.Sprintf(
+		"/Dimensions('%s')/Hierarchies('%s')/Elements?$select=Name,Attributes",
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
+	)
+
+	resp, err := es.rest.Get(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("get attribute values: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Value []struct {
+			Name       string                 `json:"Name"`
+			Attributes map[string]interface{} `json:"Attributes"`
+		} `json:"value"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode attribute values: %w", err)
+	}
+
+	elementFilter := make(map[string]bool, len(elementNames))
+	for _, name := range elementNames {
+		elementFilter[normalizeCaseSpace(name)] = true
+	}
+
+	values := make(map[string]map[string]interface{}, len(result.Value))
+	for _, element := range result.Value {
+		if len(elementFilter) > 0 && !elementFilter[normalizeCaseSpace(element.Name)] {
+			continue
+		}
+		if len(attributeNames) == 0 {
+			values[element.Name] = element.Attributes
+			continue
+		}
+
+		byKey := make(map[string]interface{}, len(element.Attributes))
+		for name, value := range element.Attributes {
+			byKey[normalizeCaseSpace(name)] = value
+		}
+		elementValues := make(map[string]interface{}, len(attributeNames))
+		for _, name := range attributeNames {
+			if value, ok := byKey[normalizeCaseSpace(name)]; ok {
+				elementValues[name] = value
+			}
+		}
+		values[element.Name] = elementValues
+	}
+
+	return values, nil
+}
+
+// WriteAttributeValues writes element attribute values, given as map[element]map[attribute]value, to the
+// element attributes cube in chunks (see WriteValuesByCoordsChunked).
+// Values are converted to the type of the attribute: numeric attributes accept numbers and numeric strings,
+// string and alias attributes accept any value. Alias values must be unique across the element names and
+// aliases of the hierarchy; conflicts are reported before anything is written.
+func (es *ElementService) WriteAttributeValues(ctx context.Context, dimensionName, hierarchyName string, values map[string]map[string]interface{}, params WriteParams) (*WriteReport, error) {
+	if hierarchyName == "" {
+		hierarchyName = dimensionName
+	}
+	if len(values) == 0 {
+		return &WriteReport{}, nil
+	}
+
+	attributes, err := es.GetElementAttributes(ctx, dimensionName, hierarchyName)
+	if err != nil {
+		return nil, err
+	}
+	attributeTypes := make(map[string]models.ElementAttribute, len(attributes))
+	for _, attribute := range attributes {
+		attributeTypes[normalizeCaseSpace(attribute.Name)] = attribute
+	}
+
+	elementNames := make([]string, 0, len(values))
+	for element := range values {
+		elementNames = append(elementNames, element)
+	}
+	sort.Strings(elementNames)
+
+	var coords [][]string
+	var cellValues []interface{}
+	aliases := make(map[string]map[string]string) // alias -> element -> value
+	for _, element := range elementNames {
+		attributeNames := make([]string, 0, len(values[element]))
+		for name := range values[element] {
+			attributeNames = append(attributeNames, name)
+		}
+		sort.Strings(attributeNames)
+
+		for _, name := range attributeNames {
+			attribute, ok := attributeTypes[normalizeCaseSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("attribute %s does not exist in %s:%s", name, dimensionName, hierarchyName)
+			}
+			value, err := attributeCellValue(attribute, values[element][name])
+			if err != nil {
+				return nil, fmt.Errorf("element %s: %w", element, err)
+			}
+			if attribute.AttributeType == models.AttributeTypeAlias {
+				if aliases[attribute.Name] == nil {
+					aliases[attribute.Name] = make(map[string]string)
+				}
+				aliases[attribute.Name][element] = value.(string)
+			}
+			coords = append(coords, []string{element, attribute.Name})
+			cellValues = append(cellValues, value)
+		}
+	}
+
+	if len(aliases) > 0 {
+		if err := es.checkAliasValues(ctx, dimensionName, hierarchyName, attributes, aliases); err != nil {
+			return nil, err
+		}
+	}
+
+	attributeCube := "}ElementAttributes_" + dimensionName
+	elementDimension := dimensionName
+	if !strings.EqualFold(hierarchyName, dimensionName) {
+		elementDimension += ":" + hierarchyName
+	}
+	return NewCellService(es.rest).WriteValuesByCoordsChunked(ctx, attributeCube, coords, cellValues, []string{elementDimension, attributeCube}, "", params)
+}
+
+// attributeCellValue converts a value to the type of the attribute.
+func attributeCellValue(attribute models.ElementAttribute, value interface{}) (interface{}, error) {
+	if attribute.AttributeType != models.AttributeTypeNumeric {
+		if value == nil {
+			return "", nil
+		}
+		return fmt.Sprint(value), nil
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return 0.0, nil
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0.0, nil
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for numeric attribute %s", v, attribute.Name)
+		}
+		return number, nil
+	default:
+		return nil, fmt.Errorf("invalid value %v (%T) for numeric attribute %s", value, value, attribute.Name)
+	}
+}
+
+// checkAliasValues verifies that the new alias values neither repeat each other nor name another
+// element, through its name or any of its aliases after the write.
+func (es *ElementService) checkAliasValues(ctx context.Context, dimensionName, hierarchyName string, attributes []models.ElementAttribute, newValues map[string]map[string]string) error {
+	var aliasNames []string
+	for _, attribute := range attributes {
+		if attribute.AttributeType == models.AttributeTypeAlias {
+			aliasNames = append(aliasNames, attribute.Name)
+		}
+	}
+	current, err := es.GetAttributeValues(ctx, dimensionName, hierarchyName, aliasNames, nil)
+	if err != nil {
+		return err
+	}
+
+	// owners maps every name and alias value to the element it identifies
+	owners := make(map[string]string)
+	claim := func(name, element string) string {
+		if name == "" {
+			return ""
+		}
+		key := normalizeCaseSpace(name)
+		if owner, ok := owners[key]; ok && !strings.EqualFold(normalizeCaseSpace(owner), normalizeCaseSpace(element)) {
+			return owner
+		}
+		owners[key] = element
+		return ""
+	}
+
+	elements := make([]string, 0, len(current))
+	for element := range current {
+		elements = append(elements, element)
+	}
+	sort.Strings(elements)
+	for _, element := range elements {
+		claim(element, element)
+	}
+
+	newByElement := make(map[string]map[string]string)
+	for attribute, byElement := range newValues {
+		for element, value := range byElement {
+			key := normalizeCaseSpace(element)
+			if newByElement[key] == nil {
+				newByElement[key] = make(map[string]string)
+			}
+			newByElement[key][normalizeCaseSpace(attribute)] = value
+		}
+	}
+	for _, element := range elements {
+		for _, attribute := range aliasNames {
+			if _, replaced := newByElement[normalizeCaseSpace(element)][normalizeCaseSpace(attribute)]; replaced {
+				continue
+			}
+			if value, ok := current[element][attribute].(string); ok {
+				claim(value, element)
+			}
+		}
+	}
+
+	var conflicts []string
+	for _, attribute := range aliasNames {
+		byElement := newValues[attribute]
+		names := make([]string, 0, len(byElement))
+		for element := range byElement {
+			names = append(names, element)
+		}
+		sort.Strings(names)
+		for _, element := range names {
+			if owner := claim(byElement[element], element); owner != "" {
+				conflicts = append(conflicts, fmt.Sprintf("%s '%s' of %s is already used by %s", attribute, byElement[element], element, owner))
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("alias values are not unique: %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package tm1
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -522,3 +523,94 @@ func TestElementService_ExecuteSetMDX(t *testing.T) {
 		t.Errorf("expected one tuple with one member, got tuples=%d", len(axis.Tuples))
 	}
 }
+
+func TestElementService_GetAttributeValues(t *testing.T) {
+	service, server := setupTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Dimensions('Region')/Hierarchies('Region')/Elements" || r.URL.RawQuery != "$select=Name,Attributes" {
+			t.Errorf("unexpected request: %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"value":[
+			{"Name":"North","Attributes":{"Code":"N","Sort Order":1}},
+			{"Name":"South","Attributes":{"Code":"S","Sort Order":2}}
+		]}`))
+	}))
+	defer server.Close()
+
+	values, err := service.GetAttributeValues(context.Background(), "Region", "", []string{"sortorder"}, []string{"south"})
+	if err != nil {
+		t.Fatalf("GetAttributeValues() failed: %v", err)
+	}
+	if len(values) != 1 || values["South"]["sortorder"] != 2.0 {
+		t.Errorf("values = %v", values)
+	}
+
+	all, err := service.GetAttributeValues(context.Background(), "Region", "Region", nil, nil)
+	if err != nil {
+		t.Fatalf("GetAttributeValues() failed: %v", err)
+	}
+	if len(all) != 2 || all["North"]["Code"] != "N" {
+		t.Errorf("values = %v", all)
+	}
+}
+
+func TestElementService_WriteAttributeValues(t *testing.T) {
+	var updates []map[string]interface{}
+	service, server := setupTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ElementAttributes"):
+			w.Write([]byte(`{"value":[{"Name":"Code","Type":"Alias"},{"Name":"Sort Order","Type":"Numeric"},{"Name":"Color","Type":"String"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/Elements"):
+			w.Write([]byte(`{"value":[
+				{"Name":"North","Attributes":{"Code":"N","Sort Order":1,"Color":""}},
+				{"Name":"South","Attributes":{"Code":"S","Sort Order":2,"Color":""}},
+				{"Name":"East","Attributes":{"Code":"","Sort Order":3,"Color":""}}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, "/tm1.Update"):
+			var chunk []map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&chunk)
+			updates = append(updates, chunk...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	report, err := service.WriteAttributeValues(context.Background(), "Region", "", map[string]map[string]interface{}{
+		"North": {"code": "S", "Sort Order": "10"},
+		"South": {"Code": "N", "Color": 7},
+	}, WriteParams{ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("WriteAttributeValues() failed: %v", err)
+	}
+	if report.Chunks != 2 || report.CellsWritten != 4 {
+		t.Errorf("report = %+v", report)
+	}
+	if len(updates) != 4 || updates[0]["Value"] != 10.0 || updates[3]["Value"] != "7" {
+		t.Errorf("updates = %v", updates)
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]map[string]interface{}
+		wantErr string
+	}{
+		{name: "alias of other element", values: map[string]map[string]interface{}{"East": {"Code": "n"}}, wantErr: "Code 'n' of East is already used by North"},
+		{name: "alias equal to element name", values: map[string]map[string]interface{}{"East": {"Code": "South"}}, wantErr: "already used by South"},
+		{name: "duplicate new aliases", values: map[string]map[string]interface{}{"East": {"Code": "X"}, "North": {"Code": "X"}}, wantErr: "'X' of North is already used by East"},
+		{name: "invalid number", values: map[string]map[string]interface{}{"East": {"Sort Order": "high"}}, wantErr: "invalid value 'high'"},
+		{name: "unknown attribute", values: map[string]map[string]interface{}{"East": {"Size": 1}}, wantErr: "attribute Size does not exist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates = nil
+			_, err := service.WriteAttributeValues(context.Background(), "Region", "Region", tt.values, WriteParams{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+			if len(updates) != 0 {
+				t.Errorf("values were written: %v", updates)
+			}
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/url"
 	"strings"
 
 	"github.com/andreyea/tm1go/pkg/models"
@@ -59,7 +58,7 @@ func (fs *FileService) GetAllNames(ctx context.Context, numberOfLevels int) ([]s
 // If contentPath is empty, the file will be retrieved from the root folder.
 func (fs *FileService) Get(ctx context.Context, fileName string, contentPath []string) ([]byte, error) {
 	endpoint := fs.buildContentsEndpoint(contentPath)
-	endpoint += fmt.Sprintf("/Contents('%s')/Content", url.PathEscape(fileName))
+	endpoint += fmt.Sprintf("/Contents('%s')/Content", escapeODataKey(fileName))
 
 	resp, err := fs.rest.Get(ctx, endpoint, WithHeader("Accept-Encoding", "gzip, deflate"))
 	if err != nil {
@@ -115,7 +114,7 @@ func (fs *FileService) Create(ctx context.Context, fileName string, contentPath
 // If contentPath is empty, the file will be updated in the root folder.
 func (fs *FileService) Update(ctx context.Context, fileName string, contentPath []string, file []byte) error {
 	endpoint := fs.buildContentsEndpoint(contentPath)
-	endpoint += fmt.Sprintf("/Contents('%s')/Content", url.PathEscape(fileName))
+	endpoint += fmt.Sprintf("/Contents('%s')/Content", escapeODataKey(fileName))
 
 	exists, err := fs.existsByEndpoint(ctx, endpoint)
 	if err != nil {
@@ -138,7 +137,7 @@ func (fs *FileService) Update(ctx context.Context, fileName string, contentPath
 // If contentPath is empty, the file will be updated in the root folder.
 func (fs *FileService) UpdateCompressed(ctx context.Context, fileName string, contentPath []string, file []byte) error {
 	endpoint := fs.buildContentsEndpoint(contentPath)
-	endpoint += fmt.Sprintf("/Contents('%s')/Content", url.PathEscape(fileName))
+	endpoint += fmt.Sprintf("/Contents('%s')/Content", escapeODataKey(fileName))
 
 	exists, err := fs.existsByEndpoint(ctx, endpoint)
 	if err != nil {
@@ -191,7 +190,7 @@ func (fs *FileService) UpdateOrCreate(ctx context.Context, fileName string, cont
 // If contentPath is empty, the file will be checked in the root folder.
 func (fs *FileService) Exists(ctx context.Context, fileName string, contentPath []string) (bool, error) {
 	endpoint := fs.buildContentsEndpoint(contentPath)
-	endpoint += fmt.Sprintf("/Contents('%s')", url.PathEscape(fileName))
+	endpoint += fmt.Sprintf("/Contents('%s')", escapeODataKey(fileName))
 
 	resp, err := fs.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -208,7 +207,7 @@ func (fs *FileService) Exists(ctx context.Context, fileName string, contentPath
 // Delete deletes a file.
 func (fs *FileService) Delete(ctx context.Context, fileName string, contentPath []string) error {
 	endpoint := fs.buildContentsEndpoint(contentPath)
-	endpoint += fmt.Sprintf("/Contents('%s')", url.PathEscape(fileName))
+	endpoint += fmt.Sprintf("/Contents('%s')", escapeODataKey(fileName))
 
 	resp, err := fs.rest.Delete(ctx, endpoint)
 	if err != nil {
@@ -249,7 +248,7 @@ func (fs *FileService) buildContentsEndpoint(contentPath []string) string {
 	endpoint := fmt.Sprintf("/Contents('%s')", base)
 
 	for _, path := range contentPath {
-		endpoint += fmt.Sprintf("/Contents('%s')", url.PathEscape(path))
+		endpoint += fmt.Sprintf("/Contents('%s')", escapeODataKey(path))
 	}
 
 	return endpoint
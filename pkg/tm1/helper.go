@@ -133,6 +133,12 @@ func EncodeODataQuery(values url.Values) string {
 	return strings.ReplaceAll(encoded, "+", "%20")
 }
 
+// escapeODataKey escapes a name for use inside a quoted OData key segment such as
+// Cubes('name'): single quotes are doubled and the result is path escaped.
+func escapeODataKey(name string) string {
+	return url.PathEscape(strings.ReplaceAll(name, "'", "''"))
+}
+
 // SliceContains checks if a slice contains a value.
 func SliceContains[T comparable](slice []T, value T) bool {
 	for _, v := range slice {
@@ -192,3 +198,52 @@ func ExtractDimensionHierarchyFromString(input string) (string, string) {
 
 	return input, input
 }
+
+// splitUniqueName splits an MDX unique name such as [dimension].[hierarchy].[element]
+// into its unescaped segments. It returns nil if the input is not a bracketed unique name.
+func splitUniqueName(uniqueName string) []string {
+	parts, ok := parseUniqueNameSegments(uniqueName)
+	if !ok {
+		return nil
+	}
+	return parts
+}
+
+// parseUniqueNameSegments parses the leading bracketed segments of a unique name. ok is false
+// when parsing stopped before the end of the input, e.g. at the "^" of [d].[h].[parent]^[child].
+func parseUniqueNameSegments(uniqueName string) (parts []string, ok bool) {
+	uniqueName = strings.TrimSpace(uniqueName)
+	for i := 0; i < len(uniqueName); {
+		if uniqueName[i] != '[' {
+			return parts, false
+		}
+		var segment strings.Builder
+		closed := false
+		j := i + 1
+		for j < len(uniqueName) {
+			if uniqueName[j] == ']' {
+				if j+1 < len(uniqueName) && uniqueName[j+1] == ']' {
+					segment.WriteByte(']')
+					j += 2
+					continue
+				}
+				closed = true
+				break
+			}
+			segment.WriteByte(uniqueName[j])
+			j++
+		}
+		if !closed {
+			return parts, false
+		}
+		parts = append(parts, segment.String())
+		i = j + 1
+		if i < len(uniqueName) {
+			if uniqueName[i] != '.' {
+				return parts, false
+			}
+			i++
+		}
+	}
+	return parts, len(parts) > 0
+}
@@ -0,0 +1,547 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/andreyea/tm1go/pkg/models"
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+// HierarchyDataFrameLayout selects how FromDataFrame reads the rows of a DataFrame.
+type HierarchyDataFrameLayout int
+
+const (
+	// ParentChildLayout has one row per edge: parent, child, and optionally weight and type.
+	// Rows with an empty parent define top-level elements.
+	ParentChildLayout HierarchyDataFrameLayout = iota
+	// LevelColumnsLayout has one row per leaf with its ancestors in level columns L0 (leaf) to Ln (top).
+	// Empty level cells are skipped, so ragged hierarchies are supported.
+	LevelColumnsLayout
+)
+
+// HierarchyDataFrameParams describes the columns FromDataFrame reads.
+// Attribute columns may carry a type suffix: "Name:a" (alias), "Name:s" (string) or "Name:n" (numeric).
+// Without a suffix, float and int columns become numeric attributes and all others string attributes.
+// Attribute values apply to the child (parent-child layout) or leaf (level columns layout) of each row.
+type HierarchyDataFrameParams struct {
+	Layout               HierarchyDataFrameLayout
+	ParentColumn         string   // Default: "Parent"
+	ChildColumn          string   // Default: "Child"
+	WeightColumn         string   // Default: "Weight"; optional, weights default to 1
+	TypeColumn           string   // Default: "Type"; optional, values N/S/C or Numeric/String/Consolidated
+	LevelColumns         []string // Default: the columns named L0, L1, ... ordered by level
+	AttributeColumns     []string // Default: all columns not used otherwise
+	AllowMultipleParents bool     // Accept elements with more than one parent
+	DeleteElements       bool     // Delete elements of an existing hierarchy missing from the DataFrame, with their data
+	DeleteAttributes     bool     // Delete element attributes missing from the DataFrame, with their values
+	DryRun               bool     // Compute the change plan without writing anything
+
+	// AllowElementTypeChanges deletes and adds again existing elements whose type differs from the
+	// DataFrame, losing their data and attribute values. Without it, such changes are an error.
+	AllowElementTypeChanges bool
+
+	// AllowAttributeTypeChanges recreates existing attributes whose type differs from the DataFrame,
+	// losing their values. Without it, such changes are an error.
+	AllowAttributeTypeChanges bool
+}
+
+// syncOptions returns the Sync options for an existing hierarchy: elements and attributes missing
+// from the DataFrame are kept unless their deletion is requested.
+func (p HierarchyDataFrameParams) syncOptions() HierarchySyncOptions {
+	return HierarchySyncOptions{
+		DryRun:         p.DryRun,
+		KeepElements:   !p.DeleteElements,
+		KeepAttributes: !p.DeleteAttributes,
+
+		AllowElementTypeChanges:   p.AllowElementTypeChanges,
+		AllowAttributeTypeChanges: p.AllowAttributeTypeChanges,
+	}
+}
+
+// HierarchyDataFrameResult is the outcome of FromDataFrame.
+type HierarchyDataFrameResult struct {
+	Hierarchy       *models.Hierarchy
+	AttributeValues map[string]map[string]interface{} // element -> attribute -> value
+	Duplicates      []string                          // rows repeating an element or edge of an earlier row, which were skipped
+	MultipleParents map[string][]string               // elements with more than one parent
+	Plan            *HierarchyChangePlan              // changes to the hierarchy on the server
+}
+
+var levelColumnPattern = regexp.MustCompile(`^[Ll](\d+)$`)
+
+// FromDataFrame builds a hierarchy from a DataFrame and creates or updates it on the server in one call:
+// the dimension and hierarchy are created when missing, an existing hierarchy is reconciled with Sync,
+// and attribute values are written to the element attributes cube. Elements and attributes of an
+// existing hierarchy that are missing from the DataFrame are kept unless DeleteElements or
+// DeleteAttributes is set, and existing elements only change type when the Type column or their
+// children in the DataFrame demand it. Elements without a type are created as Numeric.
+// Duplicate rows are reported in the result. Conflicting rows, and elements with multiple parents unless
+// AllowMultipleParents is set, are errors; the result is returned with the error and nothing is written.
+func (hs *HierarchyService) FromDataFrame(ctx context.Context, dimensionName, hierarchyName string, df dataframe.DataFrame, params HierarchyDataFrameParams) (*HierarchyDataFrameResult, error) {
+	if hierarchyName == "" {
+		hierarchyName = dimensionName
+	}
+
+	result, err := BuildHierarchyFromDataFrame(dimensionName, hierarchyName, df, params)
+	if err != nil {
+		return result, err
+	}
+	hierarchy := result.Hierarchy
+
+	exists, err := hs.Exists(ctx, dimensionName, hierarchyName)
+	if err != nil {
+		return result, err
+	}
+
+	if exists {
+		plan, err := hs.Sync(ctx, hierarchy, params.syncOptions())
+		result.Plan = plan
+		if err != nil {
+			return result, err
+		}
+	} else {
+		plan, err := planHierarchySync(&models.Hierarchy{}, hierarchy, params.syncOptions())
+		if err != nil {
+			return result, err
+		}
+		result.Plan = plan
+		if params.DryRun {
+			return result, nil
+		}
+
+		for i := range hierarchy.Elements {
+			if hierarchy.Elements[i].Type == "" {
+				hierarchy.Elements[i].Type = models.ElementTypeNumeric
+			}
+		}
+
+		dimensions := NewDimensionService(hs.rest)
+		dimensionExists, err := dimensions.Exists(ctx, dimensionName)
+		if err != nil {
+			return result, err
+		}
+		if dimensionExists {
+			err = hs.Create(ctx, hierarchy)
+		} else {
+			dimension := models.NewDimension(dimensionName)
+			dimension.AddHierarchy(*hierarchy)
+			err = dimensions.Create(ctx, dimension)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if params.DryRun || len(result.AttributeValues) == 0 {
+		return result, nil
+	}
+	report, err := hs.elements.WriteAttributeValues(ctx, dimensionName, hierarchyName, result.AttributeValues, WriteParams{})
+	if err != nil {
+		return result, fmt.Errorf("write attribute values: %w", err)
+	}
+	if err := report.Err(); err != nil {
+		return result, fmt.Errorf("write attribute values: %w", err)
+	}
+	return result, nil
+}
+
+// BuildHierarchyFromDataFrame builds a hierarchy and its attribute values from a DataFrame without
+// contacting the server. See FromDataFrame. Elements whose type is neither given by the Type column
+// nor implied by children have no type: Sync keeps the type of such elements when they exist and
+// adds them as Numeric otherwise.
+func BuildHierarchyFromDataFrame(dimensionName, hierarchyName string, df dataframe.DataFrame, params HierarchyDataFrameParams) (*HierarchyDataFrameResult, error) {
+	if dimensionName == "" {
+		return nil, fmt.Errorf("dimension name is required")
+	}
+	if hierarchyName == "" {
+		hierarchyName = dimensionName
+	}
+
+	builder := &hierarchyDataFrameBuilder{
+		hierarchy:     models.NewHierarchy(hierarchyName, dimensionName),
+		elementIndex:  make(map[string]int),
+		elementRow:    make(map[string]int),
+		explicitTypes: make(map[string]models.ElementType),
+		edgeIndex:     make(map[[2]string]int),
+		edgeRow:       make(map[[2]string]int),
+		parents:       make(map[string][]string),
+		result: &HierarchyDataFrameResult{
+			AttributeValues: make(map[string]map[string]interface{}),
+			MultipleParents: make(map[string][]string),
+		},
+	}
+	builder.result.Hierarchy = builder.hierarchy
+
+	columns := make(map[string]bool)
+	for _, name := range df.Names() {
+		columns[name] = true
+	}
+	used := make(map[string]bool)
+	optionalColumn := func(name, defaultName string) (string, error) {
+		if name == "" {
+			name = defaultName
+			if !columns[name] {
+				return "", nil
+			}
+		}
+		if !columns[name] {
+			return "", fmt.Errorf("column '%s' not found in dataframe", name)
+		}
+		used[name] = true
+		return name, nil
+	}
+
+	weightColumn, err := optionalColumn(params.WeightColumn, "Weight")
+	if err != nil {
+		return nil, err
+	}
+	typeColumn, err := optionalColumn(params.TypeColumn, "Type")
+	if err != nil {
+		return nil, err
+	}
+
+	var parentColumn, childColumn string
+	var levelColumns []string
+	switch params.Layout {
+	case ParentChildLayout:
+		if parentColumn, err = optionalColumn(params.ParentColumn, "Parent"); err != nil {
+			return nil, err
+		}
+		if childColumn, err = optionalColumn(params.ChildColumn, "Child"); err != nil {
+			return nil, err
+		}
+		if parentColumn == "" || childColumn == "" {
+			return nil, fmt.Errorf("parent-child layout requires parent and child columns")
+		}
+	case LevelColumnsLayout:
+		levelColumns = params.LevelColumns
+		if len(levelColumns) == 0 {
+			levelColumns = defaultLevelColumns(df.Names())
+		}
+		if len(levelColumns) == 0 {
+			return nil, fmt.Errorf("level columns layout requires level columns")
+		}
+		for _, name := range levelColumns {
+			if _, err := optionalColumn(name, name); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown hierarchy dataframe layout %d", params.Layout)
+	}
+
+	attributeColumns := params.AttributeColumns
+	if len(attributeColumns) == 0 {
+		for _, name := range df.Names() {
+			if !used[name] {
+				attributeColumns = append(attributeColumns, name)
+			}
+		}
+	}
+	for _, name := range attributeColumns {
+		if !columns[name] {
+			return nil, fmt.Errorf("attribute column '%s' not found in dataframe", name)
+		}
+		builder.attributes = append(builder.attributes, dataFrameAttribute(df.Col(name)))
+	}
+	for _, attribute := range builder.attributes {
+		builder.hierarchy.AddElementAttribute(attribute.ElementAttribute)
+	}
+
+	for row := 0; row < df.Nrow(); row++ {
+		weight := 1.0
+		if weightColumn != "" {
+			if value := dataFrameCell(df, weightColumn, row); value != "" {
+				if weight, err = strconv.ParseFloat(value, 64); err != nil {
+					return builder.result, fmt.Errorf("row %d: invalid weight '%s'", row, value)
+				}
+			}
+		}
+		var elementType models.ElementType
+		if typeColumn != "" {
+			if elementType, err = parseElementType(dataFrameCell(df, typeColumn, row)); err != nil {
+				return builder.result, fmt.Errorf("row %d: %w", row, err)
+			}
+		}
+
+		var element string
+		if params.Layout == ParentChildLayout {
+			element, err = builder.addParentChildRow(row, dataFrameCell(df, parentColumn, row), dataFrameCell(df, childColumn, row), weight)
+		} else {
+			path := make([]string, 0, len(levelColumns))
+			for _, name := range levelColumns {
+				if value := dataFrameCell(df, name, row); value != "" {
+					path = append(path, value)
+				}
+			}
+			element, err = builder.addLevelRow(row, path, weight)
+		}
+		if err != nil {
+			return builder.result, err
+		}
+
+		if err := builder.setType(element, elementType); err != nil {
+			return builder.result, fmt.Errorf("row %d: %w", row, err)
+		}
+		if err := builder.setAttributeValues(row, element, df, attributeColumns); err != nil {
+			return builder.result, err
+		}
+	}
+
+	return builder.result, builder.finish(params.AllowMultipleParents)
+}
+
+// dataFrameAttributeColumn is an attribute read from a DataFrame column.
+type dataFrameAttributeColumn struct {
+	models.ElementAttribute
+	numeric bool
+}
+
+// hierarchyDataFrameBuilder accumulates elements and edges row by row. Maps are keyed by
+// case- and space-insensitive names.
+type hierarchyDataFrameBuilder struct {
+	hierarchy     *models.Hierarchy
+	attributes    []dataFrameAttributeColumn
+	elementIndex  map[string]int
+	elementRow    map[string]int // row that defined a top-level or leaf element
+	explicitTypes map[string]models.ElementType
+	edgeIndex     map[[2]string]int
+	edgeRow       map[[2]string]int
+	parents       map[string][]string
+	result        *HierarchyDataFrameResult
+}
+
+// element returns the name of an element, adding it on first use.
+func (b *hierarchyDataFrameBuilder) element(name string) string {
+	key := normalizeCaseSpace(name)
+	if index, ok := b.elementIndex[key]; ok {
+		return b.hierarchy.Elements[index].Name
+	}
+	b.elementIndex[key] = len(b.hierarchy.Elements)
+	b.hierarchy.AddElement(models.Element{Name: name})
+	return name
+}
+
+// edge adds an edge and reports whether it is new. An edge repeated with a different weight is an error.
+func (b *hierarchyDataFrameBuilder) edge(row int, parent, child string, weight float64) (bool, error) {
+	parent, child = b.element(parent), b.element(child)
+	key := [2]string{normalizeCaseSpace(parent), normalizeCaseSpace(child)}
+	if index, ok := b.edgeIndex[key]; ok {
+		if existing := b.hierarchy.Edges[index]; existing.Weight != weight {
+			return false, fmt.Errorf("row %d: edge %s -> %s has weight %v, but %v in row %d", row, parent, child, weight, existing.Weight, b.edgeRow[key])
+		}
+		return false, nil
+	}
+	b.edgeIndex[key] = len(b.hierarchy.Edges)
+	b.edgeRow[key] = row
+	b.hierarchy.AddEdge(parent, child, weight)
+	b.parents[key[1]] = append(b.parents[key[1]], parent)
+	return true, nil
+}
+
+func (b *hierarchyDataFrameBuilder) addParentChildRow(row int, parent, child string, weight float64) (string, error) {
+	if child == "" {
+		return "", fmt.Errorf("row %d: child is empty", row)
+	}
+	if parent == "" {
+		key := normalizeCaseSpace(child)
+		if first, ok := b.elementRow[key]; ok {
+			b.result.Duplicates = append(b.result.Duplicates, fmt.Sprintf("row %d: element %s repeats row %d", row, child, first))
+		} else {
+			b.elementRow[key] = row
+		}
+		return b.element(child), nil
+	}
+
+	added, err := b.edge(row, parent, child, weight)
+	if err != nil {
+		return "", err
+	}
+	if !added {
+		key := [2]string{normalizeCaseSpace(parent), normalizeCaseSpace(child)}
+		b.result.Duplicates = append(b.result.Duplicates, fmt.Sprintf("row %d: edge %s -> %s repeats row %d", row, parent, child, b.edgeRow[key]))
+	}
+	return b.element(child), nil
+}
+
+// addLevelRow adds the path of a leaf, ordered from leaf to top. The weight applies to the leaf edge.
+func (b *hierarchyDataFrameBuilder) addLevelRow(row int, path []string, weight float64) (string, error) {
+	if len(path) == 0 {
+		return "", fmt.Errorf("row %d: all level columns are empty", row)
+	}
+	leaf := path[0]
+	key := normalizeCaseSpace(leaf)
+	if len(path) == 1 {
+		if first, ok := b.elementRow[key]; ok {
+			b.result.Duplicates = append(b.result.Duplicates, fmt.Sprintf("row %d: element %s repeats row %d", row, leaf, first))
+		} else {
+			b.elementRow[key] = row
+		}
+		return b.element(leaf), nil
+	}
+
+	added, err := b.edge(row, path[1], leaf, weight)
+	if err != nil {
+		return "", err
+	}
+	if !added {
+		edgeKey := [2]string{normalizeCaseSpace(path[1]), key}
+		b.result.Duplicates = append(b.result.Duplicates, fmt.Sprintf("row %d: leaf %s under %s repeats row %d", row, leaf, path[1], b.edgeRow[edgeKey]))
+	}
+	for i := 1; i+1 < len(path); i++ {
+		if _, err := b.edge(row, path[i+1], path[i], 1); err != nil {
+			return "", err
+		}
+	}
+	return b.element(leaf), nil
+}
+
+// setType records an explicit element type. An element given different types is an error.
+func (b *hierarchyDataFrameBuilder) setType(element string, elementType models.ElementType) error {
+	if elementType == "" {
+		return nil
+	}
+	key := normalizeCaseSpace(element)
+	if existing, ok := b.explicitTypes[key]; ok && existing != elementType {
+		return fmt.Errorf("element %s has type %s and %s", element, existing, elementType)
+	}
+	b.explicitTypes[key] = elementType
+	return nil
+}
+
+// setAttributeValues records the non-empty attribute values of a row. Repeated rows must not disagree.
+func (b *hierarchyDataFrameBuilder) setAttributeValues(row int, element string, df dataframe.DataFrame, columns []string) error {
+	for i, attribute := range b.attributes {
+		raw := dataFrameCell(df, columns[i], row)
+		if raw == "" {
+			continue
+		}
+		var value interface{} = raw
+		if attribute.numeric {
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return fmt.Errorf("row %d: invalid value '%s' for numeric attribute %s", row, raw, attribute.Name)
+			}
+			value = number
+		}
+
+		values := b.result.AttributeValues[element]
+		if values == nil {
+			values = make(map[string]interface{})
+			b.result.AttributeValues[element] = values
+		}
+		if existing, ok := values[attribute.Name]; ok && existing != value {
+			return fmt.Errorf("row %d: element %s has %s '%v' and '%v'", row, element, attribute.Name, existing, value)
+		}
+		values[attribute.Name] = value
+	}
+	return nil
+}
+
+// finish sets the element types given by the Type column or implied by children, and reports
+// elements with multiple parents. Other elements are left without a type.
+func (b *hierarchyDataFrameBuilder) finish(allowMultipleParents bool) error {
+	hasChildren := make(map[string]bool)
+	for key := range b.edgeIndex {
+		hasChildren[key[0]] = true
+	}
+
+	for i := range b.hierarchy.Elements {
+		element := &b.hierarchy.Elements[i]
+		key := normalizeCaseSpace(element.Name)
+		explicitType, hasType := b.explicitTypes[key]
+		switch {
+		case hasChildren[key] && hasType && explicitType != models.ElementTypeConsolidated:
+			return fmt.Errorf("element %s has children but type %s", element.Name, explicitType)
+		case hasChildren[key]:
+			element.Type = models.ElementTypeConsolidated
+		case hasType:
+			element.Type = explicitType
+		}
+	}
+
+	for key, parents := range b.parents {
+		if len(parents) > 1 {
+			name := b.hierarchy.Elements[b.elementIndex[key]].Name
+			b.result.MultipleParents[name] = parents
+		}
+	}
+	if len(b.result.MultipleParents) > 0 && !allowMultipleParents {
+		names := make([]string, 0, len(b.result.MultipleParents))
+		for name := range b.result.MultipleParents {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("elements with multiple parents: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// defaultLevelColumns returns the columns named L0, L1, ... ordered by level.
+func defaultLevelColumns(names []string) []string {
+	levels := make(map[int]string)
+	var order []int
+	for _, name := range names {
+		if match := levelColumnPattern.FindStringSubmatch(name); match != nil {
+			level, _ := strconv.Atoi(match[1])
+			levels[level] = name
+			order = append(order, level)
+		}
+	}
+	sort.Ints(order)
+	columns := make([]string, 0, len(order))
+	for _, level := range order {
+		columns = append(columns, levels[level])
+	}
+	return columns
+}
+
+// dataFrameAttribute derives the attribute name and type from a column.
+func dataFrameAttribute(col series.Series) dataFrameAttributeColumn {
+	name := col.Name
+	if i := strings.LastIndex(name, ":"); i > 0 {
+		switch strings.ToLower(name[i+1:]) {
+		case "a":
+			return dataFrameAttributeColumn{ElementAttribute: models.ElementAttribute{Name: name[:i], AttributeType: models.AttributeTypeAlias}}
+		case "s":
+			return dataFrameAttributeColumn{ElementAttribute: models.ElementAttribute{Name: name[:i], AttributeType: models.AttributeTypeString}}
+		case "n":
+			return dataFrameAttributeColumn{ElementAttribute: models.ElementAttribute{Name: name[:i], AttributeType: models.AttributeTypeNumeric}, numeric: true}
+		}
+	}
+	if col.Type() == series.Float || col.Type() == series.Int {
+		return dataFrameAttributeColumn{ElementAttribute: models.ElementAttribute{Name: name, AttributeType: models.AttributeTypeNumeric}, numeric: true}
+	}
+	return dataFrameAttributeColumn{ElementAttribute: models.ElementAttribute{Name: name, AttributeType: models.AttributeTypeString}}
+}
+
+// dataFrameCell returns a trimmed cell as string, or "" for missing values.
+func dataFrameCell(df dataframe.DataFrame, column string, row int) string {
+	elem := df.Col(column).Elem(row)
+	if elem.IsNA() {
+		return ""
+	}
+	return strings.TrimSpace(elem.String())
+}
+
+// parseElementType parses N/S/C or the full type name. An empty value has no type.
+func parseElementType(value string) (models.ElementType, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return "", nil
+	case "n", "numeric":
+		return models.ElementTypeNumeric, nil
+	case "s", "string":
+		return models.ElementTypeString, nil
+	case "c", "consolidated":
+		return models.ElementTypeConsolidated, nil
+	default:
+		return "", fmt.Errorf("unknown element type '%s'", value)
+	}
+}
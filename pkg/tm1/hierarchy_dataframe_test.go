@@ -0,0 +1,328 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreyea/tm1go/pkg/models"
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+func TestBuildHierarchyFromDataFrameParentChild(t *testing.T) {
+	df := dataframe.New(
+		series.New([]string{"", "Total", "Total", "Total", "North"}, series.String, "Parent"),
+		series.New([]string{"Total", "North", "South", "north", "Oslo"}, series.String, "Child"),
+		series.New([]float64{1, 1, 0.5, 1, 1}, series.Float, "Weight"),
+		series.New([]string{"", "", "N", "", ""}, series.String, "Type"),
+		series.New([]string{"Everything", "N", "S", "N", "OSL"}, series.String, "Code:a"),
+		series.New([]float64{0, 10, 20, 10, 30}, series.Float, "Rank"),
+	)
+
+	result, err := BuildHierarchyFromDataFrame("Region", "", df, HierarchyDataFrameParams{})
+	if err != nil {
+		t.Fatalf("BuildHierarchyFromDataFrame() failed: %v", err)
+	}
+
+	hierarchy := result.Hierarchy
+	wantElements := []models.Element{
+		{Name: "Total", Type: models.ElementTypeConsolidated},
+		{Name: "North", Type: models.ElementTypeConsolidated},
+		{Name: "South", Type: models.ElementTypeNumeric},
+		{Name: "Oslo"}, // No type value and no children: the type is left to Sync
+	}
+	if hierarchy.Name != "Region" || !reflect.DeepEqual(hierarchy.Elements, wantElements) {
+		t.Errorf("elements = %+v", hierarchy.Elements)
+	}
+	wantEdges := []models.Edge{
+		{ParentName: "Total", ComponentName: "North", Weight: 1},
+		{ParentName: "Total", ComponentName: "South", Weight: 0.5},
+		{ParentName: "North", ComponentName: "Oslo", Weight: 1},
+	}
+	if !reflect.DeepEqual(hierarchy.Edges, wantEdges) {
+		t.Errorf("edges = %+v", hierarchy.Edges)
+	}
+	wantAttributes := []models.ElementAttribute{
+		{Name: "Code", AttributeType: models.AttributeTypeAlias},
+		{Name: "Rank", AttributeType: models.AttributeTypeNumeric},
+	}
+	if !reflect.DeepEqual(hierarchy.ElementAttributes, wantAttributes) {
+		t.Errorf("attributes = %+v", hierarchy.ElementAttributes)
+	}
+	if got := result.AttributeValues["South"]; got["Code"] != "S" || got["Rank"] != 20.0 {
+		t.Errorf("South attribute values = %v", got)
+	}
+	if len(result.Duplicates) != 1 || !strings.Contains(result.Duplicates[0], "row 3") {
+		t.Errorf("Duplicates = %v", result.Duplicates)
+	}
+}
+
+func TestBuildHierarchyFromDataFrameLevels(t *testing.T) {
+	df := dataframe.New(
+		series.New([]string{"Oslo", "Bergen", "Lyon", "Oslo"}, series.String, "L0"),
+		series.New([]string{"Norway", "Norway", "France", "Norway"}, series.String, "L1"),
+		series.New([]string{"Europe", "Europe", "", "Europe"}, series.String, "L2"),
+		series.New([]string{"World", "World", "World", "World"}, series.String, "L3"),
+		series.New([]string{"NO", "NO", "FR", "NO"}, series.String, "Country"),
+	)
+
+	result, err := BuildHierarchyFromDataFrame("City", "City", df, HierarchyDataFrameParams{Layout: LevelColumnsLayout})
+	if err != nil {
+		t.Fatalf("BuildHierarchyFromDataFrame() failed: %v", err)
+	}
+
+	var edges []string
+	for _, edge := range result.Hierarchy.Edges {
+		edges = append(edges, edge.ParentName+">"+edge.ComponentName)
+	}
+	want := "Norway>Oslo,Europe>Norway,World>Europe,Norway>Bergen,France>Lyon,World>France"
+	if strings.Join(edges, ",") != want {
+		t.Errorf("edges = %s, want %s", strings.Join(edges, ","), want)
+	}
+	if len(result.Duplicates) != 1 || !strings.Contains(result.Duplicates[0], "row 3") {
+		t.Errorf("Duplicates = %v", result.Duplicates)
+	}
+	if result.AttributeValues["Lyon"]["Country"] != "FR" {
+		t.Errorf("attribute values = %v", result.AttributeValues)
+	}
+}
+
+func TestBuildHierarchyFromDataFrameConflicts(t *testing.T) {
+	multiParent := dataframe.New(
+		series.New([]string{"Oslo", "Oslo"}, series.String, "L0"),
+		series.New([]string{"Norway", "Nordics"}, series.String, "L1"),
+	)
+	result, err := BuildHierarchyFromDataFrame("City", "", multiParent, HierarchyDataFrameParams{Layout: LevelColumnsLayout})
+	if err == nil || !strings.Contains(err.Error(), "multiple parents: Oslo") {
+		t.Errorf("error = %v, want multiple parents", err)
+	}
+	if got := result.MultipleParents["Oslo"]; !reflect.DeepEqual(got, []string{"Norway", "Nordics"}) {
+		t.Errorf("MultipleParents = %v", result.MultipleParents)
+	}
+	if _, err := BuildHierarchyFromDataFrame("City", "", multiParent, HierarchyDataFrameParams{Layout: LevelColumnsLayout, AllowMultipleParents: true}); err != nil {
+		t.Errorf("AllowMultipleParents error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		df      dataframe.DataFrame
+		wantErr string
+	}{
+		{
+			name: "weight conflict",
+			df: dataframe.New(
+				series.New([]string{"Total", "Total"}, series.String, "Parent"),
+				series.New([]string{"A", "A"}, series.String, "Child"),
+				series.New([]float64{1, 2}, series.Float, "Weight"),
+			),
+			wantErr: "has weight 2",
+		},
+		{
+			name: "consolidation with leaf type",
+			df: dataframe.New(
+				series.New([]string{"", "Total"}, series.String, "Parent"),
+				series.New([]string{"Total", "A"}, series.String, "Child"),
+				series.New([]string{"S", "N"}, series.String, "Type"),
+			),
+			wantErr: "has children but type String",
+		},
+		{
+			name: "attribute conflict",
+			df: dataframe.New(
+				series.New([]string{"X", "Y"}, series.String, "Parent"),
+				series.New([]string{"A", "A"}, series.String, "Child"),
+				series.New([]string{"red", "blue"}, series.String, "Color"),
+			),
+			wantErr: "has Color 'red' and 'blue'",
+		},
+		{
+			name:    "missing child column",
+			df:      dataframe.New(series.New([]string{"X"}, series.String, "Parent")),
+			wantErr: "requires parent and child columns",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildHierarchyFromDataFrame("Dim", "", tt.df, HierarchyDataFrameParams{AllowMultipleParents: true})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHierarchyServiceFromDataFrame(t *testing.T) {
+	var requests []string
+	var cellUpdates []map[string]interface{}
+	attributes := `{"value":[]}`
+	service, server := setupHierarchyTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/Dimensions('Region')/Hierarchies":
+			w.Write([]byte(`{"value":[{"Name":"Region"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/Dimensions('Region')":
+			w.Write([]byte(`{"Name":"Region"}`))
+		case strings.HasSuffix(r.URL.Path, "/ElementAttributes"):
+			if r.Method == http.MethodPost {
+				attributes = `{"value":[{"Name":"Code","Type":"Alias"}]}`
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+			w.Write([]byte(attributes))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"value":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/tm1.Update"):
+			json.NewDecoder(r.Body).Decode(&cellUpdates)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	df := dataframe.New(
+		series.New([]string{"Total", "Total"}, series.String, "Parent"),
+		series.New([]string{"North", "South"}, series.String, "Child"),
+		series.New([]string{"N", "S"}, series.String, "Code:a"),
+	)
+
+	result, err := service.FromDataFrame(context.Background(), "Region", "Managers", df, HierarchyDataFrameParams{})
+	if err != nil {
+		t.Fatalf("FromDataFrame() failed: %v", err)
+	}
+	if len(result.Plan.ElementsToAdd) != 3 || len(result.Plan.EdgesToAdd) != 2 {
+		t.Errorf("plan = %+v", result.Plan)
+	}
+
+	want := []string{
+		"GET /Dimensions('Region')/Hierarchies",
+		"GET /Dimensions('Region')",
+		"POST /Dimensions('Region')/Hierarchies",
+		"GET /Dimensions('Region')/Hierarchies('Managers')/ElementAttributes",
+		"POST /Dimensions('Region')/Hierarchies('Managers')/ElementAttributes",
+		"GET /Dimensions('Region')/Hierarchies('Managers')/ElementAttributes",
+		"GET /Dimensions('Region')/Hierarchies('Managers')/Elements",
+		"POST /Cubes('}ElementAttributes_Region')/tm1.Update",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+	if len(cellUpdates) != 2 || cellUpdates[0]["Value"] != "N" {
+		t.Fatalf("cell updates = %v", cellUpdates)
+	}
+	cell := cellUpdates[0]["Cells"].([]interface{})[0].(map[string]interface{})
+	binds := cell["Tuple@odata.bind"].([]interface{})
+	if binds[0] != "Dimensions('Region')/Hierarchies('Managers')/Elements('North')" {
+		t.Errorf("element binding = %v", binds[0])
+	}
+}
+
+func TestHierarchyServiceFromDataFrameKeepsExisting(t *testing.T) {
+	var deletes []string
+	service, server := setupHierarchyTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/Dimensions('Region')/Hierarchies":
+			w.Write([]byte(`{"value":[{"Name":"Region"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/ElementAttributes"):
+			w.Write([]byte(`{"value":[{"Name":"Code","Type":"Alias"},{"Name":"Manager","Type":"String"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/Elements"):
+			w.Write([]byte(`{"value":[{"Name":"Total","Type":"Consolidated"},{"Name":"North","Type":"Numeric"},{"Name":"West","Type":"Numeric"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/Edges"):
+			w.Write([]byte(`{"value":[{"ParentName":"Total","ComponentName":"North","Weight":1},{"ParentName":"Total","ComponentName":"West","Weight":1}]}`))
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	// No attribute columns: existing attributes and their values must survive the load
+	df := dataframe.New(
+		series.New([]string{"Total", "Total"}, series.String, "Parent"),
+		series.New([]string{"North", "South"}, series.String, "Child"),
+	)
+
+	result, err := service.FromDataFrame(context.Background(), "Region", "", df, HierarchyDataFrameParams{})
+	if err != nil {
+		t.Fatalf("FromDataFrame() failed: %v", err)
+	}
+	plan := result.Plan
+	if len(plan.ElementsToDelete) != 0 || len(plan.AttributesToDelete) != 0 || len(plan.EdgesToRemove) != 0 {
+		t.Errorf("plan deletes data by default: %+v", plan)
+	}
+	if len(plan.ElementsToAdd) != 1 || plan.ElementsToAdd[0].Name != "South" {
+		t.Errorf("ElementsToAdd = %+v", plan.ElementsToAdd)
+	}
+	if len(deletes) != 0 {
+		t.Errorf("deletes = %v", deletes)
+	}
+
+	result, err = service.FromDataFrame(context.Background(), "Region", "", df, HierarchyDataFrameParams{DeleteElements: true, DeleteAttributes: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("FromDataFrame() failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Plan.ElementsToDelete, []string{"West"}) || !reflect.DeepEqual(result.Plan.AttributesToDelete, []string{"Code", "Manager"}) {
+		t.Errorf("opt-in plan = %+v", result.Plan)
+	}
+	if len(deletes) != 0 {
+		t.Errorf("dry run deletes = %v", deletes)
+	}
+}
+
+func TestHierarchyServiceFromDataFrameKeepsElementTypes(t *testing.T) {
+	service, server := setupHierarchyTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method != http.MethodGet:
+			t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+		case r.URL.Path == "/Dimensions('Region')/Hierarchies":
+			w.Write([]byte(`{"value":[{"Name":"Region"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/ElementAttributes"):
+			w.Write([]byte(`{"value":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/Elements"):
+			w.Write([]byte(`{"value":[{"Name":"Total","Type":"Consolidated"},{"Name":"North","Type":"Consolidated"},{"Name":"Oslo","Type":"String"},{"Name":"Bergen","Type":"Numeric"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/Edges"):
+			w.Write([]byte(`{"value":[{"ParentName":"Total","ComponentName":"North","Weight":1},{"ParentName":"North","ComponentName":"Bergen","Weight":1}]}`))
+		}
+	}))
+	defer server.Close()
+
+	// North is only named as a child and Oslo as a top element: both keep their types
+	df := dataframe.New(
+		series.New([]string{"Total", "", ""}, series.String, "Parent"),
+		series.New([]string{"North", "Oslo", "South"}, series.String, "Child"),
+	)
+	result, err := service.FromDataFrame(context.Background(), "Region", "", df, HierarchyDataFrameParams{DryRun: true})
+	if err != nil {
+		t.Fatalf("FromDataFrame() failed: %v", err)
+	}
+	plan := result.Plan
+	if len(plan.ElementTypeChanges) != 0 || len(plan.EdgesToRemove) != 0 {
+		t.Errorf("plan changes existing elements: %+v", plan)
+	}
+	want := []models.Element{{Name: "South", Type: models.ElementTypeNumeric}}
+	if !reflect.DeepEqual(plan.ElementsToAdd, want) {
+		t.Errorf("ElementsToAdd = %+v, want %+v", plan.ElementsToAdd, want)
+	}
+
+	// An explicit type still changes the element
+	df = dataframe.New(
+		series.New([]string{""}, series.String, "Parent"),
+		series.New([]string{"Oslo"}, series.String, "Child"),
+		series.New([]string{"N"}, series.String, "Type"),
+	)
+	result, err = service.FromDataFrame(context.Background(), "Region", "", df, HierarchyDataFrameParams{DryRun: true})
+	if err != nil {
+		t.Fatalf("FromDataFrame() failed: %v", err)
+	}
+	want = []models.Element{{Name: "Oslo", Type: models.ElementTypeNumeric}}
+	if !reflect.DeepEqual(result.Plan.ElementTypeChanges, want) {
+		t.Errorf("ElementTypeChanges = %+v, want %+v", result.Plan.ElementTypeChanges, want)
+	}
+}
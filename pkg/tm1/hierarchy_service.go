@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/url"
 	"strings"
 
 	"github.com/andreyea/tm1go/pkg/models"
@@ -28,7 +27,8 @@ func NewHierarchyService(rest *RestService) *HierarchyService {
 	}
 }
 
-// Create creates a new hierarchy in an existing dimension
+// Create creates a new hierarchy in an existing dimension. With Config.ValidateModels the
+// hierarchy is checked with models.Hierarchy.Validate before it is sent to the server.
 func (hs *HierarchyService) Create(ctx context.Context, hierarchy *models.Hierarchy) error {
 	if hierarchy.DimensionName == "" {
 		return fmt.Errorf("dimension name is required")
@@ -36,8 +36,13 @@ func (hs *HierarchyService) Create(ctx context.Context, hierarchy *models.Hierar
 	if hierarchy.Name == "" {
 		return fmt.Errorf("hierarchy name is required")
 	}
+	if hs.rest.validateModels {
+		if err := hierarchy.Validate(); err != nil {
+			return err
+		}
+	}
 
-	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies", url.PathEscape(hierarchy.DimensionName))
+	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies", escapeODataKey(hierarchy.DimensionName))
 
 	body, err := json.Marshal(hierarchy)
 	if err != nil {
@@ -64,8 +69,8 @@ func (hs *HierarchyService) Create(ctx context.Context, hierarchy *models.Hierar
 func (hs *HierarchyService) Get(ctx context.Context, dimensionName, hierarchyName string) (*models.Hierarchy, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')?$expand=Edges,Elements,ElementAttributes,Subsets,DefaultMember",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := hs.rest.Get(ctx, endpoint)
@@ -85,7 +90,7 @@ func (hs *HierarchyService) Get(ctx context.Context, dimensionName, hierarchyNam
 
 // GetAllNames retrieves all hierarchy names in a dimension
 func (hs *HierarchyService) GetAllNames(ctx context.Context, dimensionName string) ([]string, error) {
-	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies?$select=Name", url.PathEscape(dimensionName))
+	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies?$select=Name", escapeODataKey(dimensionName))
 
 	resp, err := hs.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -125,15 +130,15 @@ func (hs *HierarchyService) GetElementAttributeNames(ctx context.Context, dimens
 
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')?$expand=ElementAttributes($select=Name)",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	if attributeType != nil {
 		endpoint = fmt.Sprintf(
 			"/Dimensions('%s')/Hierarchies('%s')?$expand=ElementAttributes($select=Name;$filter=Type%%20eq%%20%d)",
-			url.PathEscape(dimensionName),
-			url.PathEscape(hierarchyName),
+			escapeODataKey(dimensionName),
+			escapeODataKey(hierarchyName),
 			*attributeType,
 		)
 	}
@@ -180,7 +185,7 @@ func (hs *HierarchyService) GetAliasAttributeNames(ctx context.Context, dimensio
 	return hs.GetElementAttributeNames(ctx, dimensionName, hierarchyName, &aliasType)
 }
 
-// Update updates an existing hierarchy
+// Update updates an existing hierarchy, validating it first like Create
 func (hs *HierarchyService) Update(ctx context.Context, hierarchy *models.Hierarchy, keepExistingAttributes bool) error {
 	if hierarchy.DimensionName == "" {
 		return fmt.Errorf("dimension name is required")
@@ -188,11 +193,16 @@ func (hs *HierarchyService) Update(ctx context.Context, hierarchy *models.Hierar
 	if hierarchy.Name == "" {
 		return fmt.Errorf("hierarchy name is required")
 	}
+	if hs.rest.validateModels {
+		if err := hierarchy.Validate(); err != nil {
+			return err
+		}
+	}
 
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')",
-		url.PathEscape(hierarchy.DimensionName),
-		url.PathEscape(hierarchy.Name),
+		escapeODataKey(hierarchy.DimensionName),
+		escapeODataKey(hierarchy.Name),
 	)
 
 	body, err := json.Marshal(hierarchy)
@@ -229,7 +239,7 @@ func (hs *HierarchyService) UpdateOrCreate(ctx context.Context, hierarchy *model
 
 // Exists checks if a hierarchy exists in a dimension
 func (hs *HierarchyService) Exists(ctx context.Context, dimensionName, hierarchyName string) (bool, error) {
-	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies?$select=Name", url.PathEscape(dimensionName))
+	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies?$select=Name", escapeODataKey(dimensionName))
 
 	resp, err := hs.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -263,8 +273,8 @@ func (hs *HierarchyService) Exists(ctx context.Context, dimensionName, hierarchy
 func (hs *HierarchyService) Delete(ctx context.Context, dimensionName, hierarchyName string) error {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := hs.rest.Delete(ctx, endpoint)
@@ -281,8 +291,8 @@ func (hs *HierarchyService) GetHierarchySummary(ctx context.Context, dimensionNa
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')?$expand=Edges/$count,Elements/$count,"+
 			"ElementAttributes/$count,Members/$count,Levels/$count&$select=Cardinality",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := hs.rest.Get(ctx, endpoint)
@@ -390,8 +400,8 @@ func (hs *HierarchyService) GetDefaultMember(ctx context.Context, dimensionName,
 
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/DefaultMember",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := hs.rest.Get(ctx, endpoint)
@@ -427,8 +437,8 @@ func (hs *HierarchyService) UpdateDefaultMember(ctx context.Context, dimensionNa
 
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	payload := map[string]string{
@@ -457,8 +467,8 @@ func (hs *HierarchyService) RemoveAllEdges(ctx context.Context, dimensionName, h
 
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	payload := map[string]interface{}{
@@ -540,8 +550,8 @@ func (hs *HierarchyService) CreateElementAttributes(ctx context.Context, dimensi
 func (hs *HierarchyService) IsBalanced(ctx context.Context, dimensionName, hierarchyName string) (bool, error) {
 	endpoint := fmt.Sprintf(
 		"/Dimensions('%s')/Hierarchies('%s')/Structure/$value",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 	)
 
 	resp, err := hs.rest.Get(ctx, endpoint)
@@ -2,10 +2,13 @@ package tm1
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/andreyea/tm1go/pkg/models"
 )
 
 func setupHierarchyTestService(handler http.HandlerFunc) (*HierarchyService, *httptest.Server) {
@@ -148,3 +151,70 @@ func TestHierarchyService_GetAttributeNameWrappers(t *testing.T) {
 		})
 	}
 }
+
+func TestHierarchyService_CreateWithValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	rest, _ := NewRestService(Config{Address: "localhost", Port: 8882, SSL: false, ValidateModels: true})
+	rest.SetBaseURL(server.URL)
+	service := NewHierarchyService(rest)
+
+	hierarchy := models.NewHierarchy("Region", "Region")
+	hierarchy.AddElement(models.Element{Name: "Total", Type: models.ElementTypeConsolidated})
+	hierarchy.AddElement(models.Element{Name: "North", Type: models.ElementTypeConsolidated})
+	hierarchy.AddElement(models.Element{Name: "no rth", Type: models.ElementTypeNumeric})
+	hierarchy.AddElement(models.Element{Name: "South", Type: models.ElementTypeNumeric})
+	hierarchy.AddElement(models.Element{Name: "Empty", Type: models.ElementTypeConsolidated})
+	hierarchy.AddElement(models.Element{Name: "Oslo", Type: models.ElementTypeString})
+	hierarchy.AddEdge("Total", "North", 1)
+	hierarchy.AddEdge("North", "Total", 1)
+	hierarchy.AddEdge("Total", "West", 1)
+	hierarchy.AddEdge("Oslo", "South", 1)
+	hierarchy.AddElementAttribute(models.ElementAttribute{Name: "South", AttributeType: models.AttributeTypeAlias})
+
+	err := service.Create(context.Background(), hierarchy)
+	var validationErr *models.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Create() error = %v, want ValidationError", err)
+	}
+	if requests != 0 {
+		t.Errorf("invalid hierarchy was sent to the server")
+	}
+
+	want := []string{
+		"hierarchy 'Region': element 'no rth': duplicate of element 'North'",
+		"hierarchy 'Region': edge 'Total' -> 'West': component element 'West' does not exist",
+		"hierarchy 'Region': element 'Oslo': has children but type 'String'",
+		"hierarchy 'Region': cycle Total -> North -> Total",
+		"hierarchy 'Region': attribute 'South': alias name collides with element 'South'",
+	}
+	var got []string
+	for _, problem := range validationErr.Problems {
+		got = append(got, problem.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	dimension := models.NewDimension("Region")
+	dimension.AddHierarchy(*hierarchy)
+	dimension.AddHierarchy(models.Hierarchy{Name: "region"})
+	if err := dimension.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate of hierarchy 'Region'") {
+		t.Errorf("Dimension.Validate() error = %v", err)
+	}
+
+	valid := models.NewHierarchy("Region", "Region")
+	valid.AddElement(models.Element{Name: "Total", Type: models.ElementTypeConsolidated})
+	valid.AddElement(models.Element{Name: "North", Type: models.ElementTypeNumeric})
+	valid.AddEdge("Total", "North", 1)
+	if err := service.Create(context.Background(), valid); err != nil {
+		t.Fatalf("Create() of valid hierarchy failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
@@ -0,0 +1,318 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+// HierarchySyncOptions controls how Sync reconciles a hierarchy
+type HierarchySyncOptions struct {
+	DryRun         bool // Compute the change plan without applying it
+	KeepElements   bool // Keep elements missing from the desired hierarchy, along with their edges
+	KeepAttributes bool // Keep element attributes missing from the desired hierarchy
+
+	// AllowElementTypeChanges lets Sync delete and add again elements whose type changed, which
+	// loses their cube data and attribute values. Without it, a plan with element type changes is not applied.
+	AllowElementTypeChanges bool
+
+	// AllowAttributeTypeChanges lets Sync delete and recreate attributes whose type changed, which
+	// loses their values. Without it, a plan with attribute type changes is not applied.
+	AllowAttributeTypeChanges bool
+}
+
+// HierarchyChangePlan lists the changes that bring a hierarchy in line with the desired definition.
+// TM1 cannot change the type of an element in place, so elements in ElementTypeChanges are
+// deleted and added again, losing their cube data and attribute values, and their desired edges
+// are part of EdgesToAdd. Attributes in AttributeTypeChanges are deleted and created again, so
+// their values are lost. Warnings lists both kinds of loss.
+type HierarchyChangePlan struct {
+	ElementsToAdd        []models.Element
+	ElementsToDelete     []string
+	ElementTypeChanges   []models.Element
+	EdgesToAdd           []models.Edge
+	EdgesToRemove        []models.Edge
+	EdgeWeightChanges    []models.Edge
+	AttributesToAdd      []models.ElementAttribute
+	AttributesToDelete   []string
+	AttributeTypeChanges []models.ElementAttribute
+	Warnings             []string // Changes that lose data
+}
+
+// IsEmpty reports whether the plan contains no changes
+func (p *HierarchyChangePlan) IsEmpty() bool {
+	return len(p.ElementsToAdd) == 0 && len(p.ElementsToDelete) == 0 && len(p.ElementTypeChanges) == 0 &&
+		len(p.EdgesToAdd) == 0 && len(p.EdgesToRemove) == 0 && len(p.EdgeWeightChanges) == 0 &&
+		len(p.AttributesToAdd) == 0 && len(p.AttributesToDelete) == 0 && len(p.AttributeTypeChanges) == 0
+}
+
+// Sync compares the desired hierarchy with the hierarchy on the server and applies only the
+// differences in elements, element types, edges, weights and element attributes. Unlike Update,
+// unchanged elements and edges are not touched. The returned plan lists the changes; with
+// DryRun nothing is applied.
+// Desired elements without a Type keep their current type, or are added as Numeric.
+// Changing the type of an element or attribute deletes it and creates it again, losing its cube
+// data or attribute values. Such changes are listed in the plan's Warnings, and Sync returns an
+// error without applying anything unless AllowElementTypeChanges or AllowAttributeTypeChanges is set.
+func (hs *HierarchyService) Sync(ctx context.Context, desired *models.Hierarchy, opts HierarchySyncOptions) (*HierarchyChangePlan, error) {
+	if desired.DimensionName == "" {
+		return nil, fmt.Errorf("dimension name is required")
+	}
+	if desired.Name == "" {
+		return nil, fmt.Errorf("hierarchy name is required")
+	}
+
+	elements, err := hs.elements.GetElements(ctx, desired.DimensionName, desired.Name)
+	if err != nil {
+		return nil, fmt.Errorf("get existing elements: %w", err)
+	}
+	edges, err := hs.elements.GetEdges(ctx, desired.DimensionName, desired.Name)
+	if err != nil {
+		return nil, fmt.Errorf("get existing edges: %w", err)
+	}
+	attributes, err := hs.elements.GetElementAttributes(ctx, desired.DimensionName, desired.Name)
+	if err != nil {
+		// If 404, no existing attributes
+		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
+			attributes = []models.ElementAttribute{}
+		} else {
+			return nil, fmt.Errorf("get existing attributes: %w", err)
+		}
+	}
+
+	existing := &models.Hierarchy{
+		Name:              desired.Name,
+		DimensionName:     desired.DimensionName,
+		Elements:          elements,
+		ElementAttributes: attributes,
+	}
+	for key, weight := range edges {
+		existing.Edges = append(existing.Edges, models.Edge{ParentName: key[0], ComponentName: key[1], Weight: weight})
+	}
+
+	plan, err := planHierarchySync(existing, desired, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun || plan.IsEmpty() {
+		return plan, nil
+	}
+	if len(plan.ElementTypeChanges) > 0 && !opts.AllowElementTypeChanges {
+		return plan, fmt.Errorf("sync would change the type of %d elements and lose their data: %s",
+			len(plan.ElementTypeChanges), strings.Join(plan.Warnings, "; "))
+	}
+	if len(plan.AttributeTypeChanges) > 0 && !opts.AllowAttributeTypeChanges {
+		return plan, fmt.Errorf("sync would change the type of %d attributes and lose their values: %s",
+			len(plan.AttributeTypeChanges), strings.Join(plan.Warnings, "; "))
+	}
+
+	if err := hs.applyHierarchyChangePlan(ctx, desired.DimensionName, desired.Name, plan); err != nil {
+		return plan, err
+	}
+	return plan, nil
+}
+
+// planHierarchySync computes the changes that turn the existing hierarchy into the desired one
+func planHierarchySync(existing, desired *models.Hierarchy, opts HierarchySyncOptions) (*HierarchyChangePlan, error) {
+	plan := &HierarchyChangePlan{}
+
+	existingElements := make(map[string]models.Element, len(existing.Elements))
+	for _, element := range existing.Elements {
+		existingElements[normalizeCaseSpace(element.Name)] = element
+	}
+
+	desiredElements := make(map[string]models.Element, len(desired.Elements))
+	retyped := make(map[string]bool)
+	for _, element := range desired.Elements {
+		key := normalizeCaseSpace(element.Name)
+		if element.Name == "" {
+			return nil, fmt.Errorf("element name is required")
+		}
+		if _, ok := desiredElements[key]; ok {
+			return nil, fmt.Errorf("element %s is defined more than once", element.Name)
+		}
+		desiredElements[key] = element
+
+		current, ok := existingElements[key]
+		switch {
+		case !ok:
+			elementType := element.Type
+			if elementType == "" {
+				elementType = models.ElementTypeNumeric
+			}
+			plan.ElementsToAdd = append(plan.ElementsToAdd, models.Element{Name: element.Name, Type: elementType})
+		case element.Type != "" && current.Type != element.Type:
+			plan.ElementTypeChanges = append(plan.ElementTypeChanges, models.Element{Name: element.Name, Type: element.Type})
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("element %s changes type from %s to %s and loses its data and attribute values",
+				element.Name, current.Type, element.Type))
+			retyped[key] = true
+		}
+	}
+
+	// Elements missing from the desired definition are deleted unless kept
+	untouched := make(map[string]bool)
+	for _, element := range existing.Elements {
+		key := normalizeCaseSpace(element.Name)
+		if _, ok := desiredElements[key]; ok {
+			continue
+		}
+		if opts.KeepElements {
+			untouched[key] = true
+		} else {
+			plan.ElementsToDelete = append(plan.ElementsToDelete, element.Name)
+		}
+	}
+
+	existingEdges := make(map[[2]string]models.Edge, len(existing.Edges))
+	for _, edge := range existing.Edges {
+		existingEdges[normalizeEdgeKey(edge)] = edge
+	}
+
+	desiredEdges := make(map[[2]string]bool, len(desired.Edges))
+	for _, edge := range desired.Edges {
+		key := normalizeEdgeKey(edge)
+		for _, name := range []string{edge.ParentName, edge.ComponentName} {
+			nameKey := normalizeCaseSpace(name)
+			if _, ok := desiredElements[nameKey]; !ok && !untouched[nameKey] {
+				return nil, fmt.Errorf("edge %s -> %s refers to unknown element %s", edge.ParentName, edge.ComponentName, name)
+			}
+		}
+		if desiredEdges[key] {
+			return nil, fmt.Errorf("edge %s -> %s is defined more than once", edge.ParentName, edge.ComponentName)
+		}
+		desiredEdges[key] = true
+
+		desiredEdge := models.Edge{ParentName: edge.ParentName, ComponentName: edge.ComponentName, Weight: edge.Weight}
+		current, ok := existingEdges[key]
+		switch {
+		case !ok || retyped[key[0]] || retyped[key[1]]:
+			plan.EdgesToAdd = append(plan.EdgesToAdd, desiredEdge)
+		case current.Weight != edge.Weight:
+			plan.EdgeWeightChanges = append(plan.EdgeWeightChanges, desiredEdge)
+		}
+	}
+
+	for _, edge := range existing.Edges {
+		key := normalizeEdgeKey(edge)
+		if desiredEdges[key] || untouched[key[0]] || untouched[key[1]] {
+			continue
+		}
+		plan.EdgesToRemove = append(plan.EdgesToRemove, edge)
+	}
+	sort.Slice(plan.EdgesToRemove, func(i, j int) bool {
+		a, b := plan.EdgesToRemove[i], plan.EdgesToRemove[j]
+		if a.ParentName != b.ParentName {
+			return a.ParentName < b.ParentName
+		}
+		return a.ComponentName < b.ComponentName
+	})
+
+	existingAttributes := make(map[string]models.ElementAttribute, len(existing.ElementAttributes))
+	for _, attribute := range existing.ElementAttributes {
+		existingAttributes[normalizeCaseSpace(attribute.Name)] = attribute
+	}
+	desiredAttributes := make(map[string]bool, len(desired.ElementAttributes))
+	for _, attribute := range desired.ElementAttributes {
+		key := normalizeCaseSpace(attribute.Name)
+		desiredAttributes[key] = true
+		current, ok := existingAttributes[key]
+		switch {
+		case !ok:
+			plan.AttributesToAdd = append(plan.AttributesToAdd, attribute)
+		case current.AttributeType != attribute.AttributeType:
+			plan.AttributeTypeChanges = append(plan.AttributeTypeChanges, attribute)
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("attribute %s changes type from %s to %s and loses its values",
+				attribute.Name, current.AttributeType, attribute.AttributeType))
+		}
+	}
+	if !opts.KeepAttributes {
+		for _, attribute := range existing.ElementAttributes {
+			if !desiredAttributes[normalizeCaseSpace(attribute.Name)] {
+				plan.AttributesToDelete = append(plan.AttributesToDelete, attribute.Name)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// applyHierarchyChangePlan applies the plan. Edges are removed before elements are deleted and
+// added after elements are created. Edges of deleted elements disappear with the element.
+func (hs *HierarchyService) applyHierarchyChangePlan(ctx context.Context, dimensionName, hierarchyName string, plan *HierarchyChangePlan) error {
+	for _, attribute := range plan.AttributesToAdd {
+		if err := hs.elements.CreateElementAttribute(ctx, dimensionName, hierarchyName, attribute); err != nil {
+			return fmt.Errorf("create attribute %s: %w", attribute.Name, err)
+		}
+	}
+
+	deleted := make(map[string]bool, len(plan.ElementsToDelete)+len(plan.ElementTypeChanges))
+	for _, name := range plan.ElementsToDelete {
+		deleted[normalizeCaseSpace(name)] = true
+	}
+	for _, element := range plan.ElementTypeChanges {
+		deleted[normalizeCaseSpace(element.Name)] = true
+	}
+
+	edgesToRemove := append(append([]models.Edge{}, plan.EdgesToRemove...), plan.EdgeWeightChanges...)
+	for _, edge := range edgesToRemove {
+		key := normalizeEdgeKey(edge)
+		if deleted[key[0]] || deleted[key[1]] {
+			continue
+		}
+		if err := hs.elements.RemoveEdge(ctx, dimensionName, hierarchyName, edge.ParentName, edge.ComponentName); err != nil {
+			return fmt.Errorf("remove edge %s -> %s: %w", edge.ParentName, edge.ComponentName, err)
+		}
+	}
+
+	for _, name := range plan.ElementsToDelete {
+		if err := hs.elements.Delete(ctx, dimensionName, hierarchyName, name); err != nil {
+			return fmt.Errorf("delete element %s: %w", name, err)
+		}
+	}
+	for _, element := range plan.ElementTypeChanges {
+		if err := hs.elements.Delete(ctx, dimensionName, hierarchyName, element.Name); err != nil {
+			return fmt.Errorf("delete element %s for type change: %w", element.Name, err)
+		}
+	}
+
+	elementsToAdd := append(append([]models.Element{}, plan.ElementsToAdd...), plan.ElementTypeChanges...)
+	if len(elementsToAdd) > 0 {
+		if err := hs.elements.AddElements(ctx, dimensionName, hierarchyName, elementsToAdd); err != nil {
+			return err
+		}
+	}
+
+	edgesToAdd := make(map[[2]string]float64, len(plan.EdgesToAdd)+len(plan.EdgeWeightChanges))
+	for _, edge := range append(append([]models.Edge{}, plan.EdgesToAdd...), plan.EdgeWeightChanges...) {
+		edgesToAdd[[2]string{edge.ParentName, edge.ComponentName}] = edge.Weight
+	}
+	if len(edgesToAdd) > 0 {
+		if err := hs.elements.AddEdges(ctx, dimensionName, hierarchyName, edgesToAdd); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range plan.AttributesToDelete {
+		if err := hs.elements.DeleteElementAttribute(ctx, dimensionName, hierarchyName, name); err != nil {
+			return fmt.Errorf("delete attribute %s: %w", name, err)
+		}
+	}
+	for _, attribute := range plan.AttributeTypeChanges {
+		if err := hs.elements.DeleteElementAttribute(ctx, dimensionName, hierarchyName, attribute.Name); err != nil {
+			return fmt.Errorf("delete attribute for update %s: %w", attribute.Name, err)
+		}
+		if err := hs.elements.CreateElementAttribute(ctx, dimensionName, hierarchyName, attribute); err != nil {
+			return fmt.Errorf("recreate attribute %s: %w", attribute.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// normalizeEdgeKey returns the normalized parent and component names of an edge
+func normalizeEdgeKey(edge models.Edge) [2]string {
+	return [2]string{normalizeCaseSpace(edge.ParentName), normalizeCaseSpace(edge.ComponentName)}
+}
@@ -0,0 +1,183 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+// newHierarchySyncServer serves the current state of Region/Region and records all changes.
+func newHierarchySyncServer(t *testing.T, requests *[]string, bodies map[string]interface{}) *HierarchyService {
+	t.Helper()
+	service, server := setupHierarchyTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/Elements"):
+			w.Write([]byte(`{"value":[{"Name":"Total","Type":"Consolidated"},{"Name":"A","Type":"Numeric"},{"Name":"B","Type":"Numeric"},{"Name":"Old","Type":"Numeric"},{"Name":"X","Type":"Numeric"}]}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/Edges"):
+			w.Write([]byte(`{"value":[{"ParentName":"Total","ComponentName":"A","Weight":1},{"ParentName":"Total","ComponentName":"B","Weight":1},{"ParentName":"Total","ComponentName":"Old","Weight":1}]}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/ElementAttributes"):
+			w.Write([]byte(`{"value":[{"Name":"Alias","Type":"Alias"},{"Name":"Old Attr","Type":"String"}]}`))
+		default:
+			request := r.Method + " " + r.URL.Path
+			*requests = append(*requests, request)
+			if r.Method == http.MethodPost {
+				var body interface{}
+				json.NewDecoder(r.Body).Decode(&body)
+				bodies[request] = body
+				w.WriteHeader(http.StatusCreated)
+			} else {
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return service
+}
+
+func desiredSyncHierarchy() *models.Hierarchy {
+	hierarchy := models.NewHierarchy("Region", "Region")
+	hierarchy.AddElement(models.Element{Name: "Total", Type: models.ElementTypeConsolidated})
+	hierarchy.AddElement(models.Element{Name: "a", Type: models.ElementTypeNumeric})
+	hierarchy.AddElement(models.Element{Name: "B", Type: models.ElementTypeNumeric})
+	hierarchy.AddElement(models.Element{Name: "C", Type: models.ElementTypeNumeric})
+	hierarchy.AddElement(models.Element{Name: "X", Type: models.ElementTypeConsolidated})
+	hierarchy.AddEdge("Total", "A", 1)
+	hierarchy.AddEdge("Total", "B", 2)
+	hierarchy.AddEdge("Total", "C", 1)
+	hierarchy.AddEdge("X", "C", 1)
+	hierarchy.AddElementAttribute(models.ElementAttribute{Name: "alias", AttributeType: models.AttributeTypeAlias})
+	hierarchy.AddElementAttribute(models.ElementAttribute{Name: "Color", AttributeType: models.AttributeTypeString})
+	return hierarchy
+}
+
+func TestHierarchyServiceSync(t *testing.T) {
+	var requests []string
+	bodies := make(map[string]interface{})
+	service := newHierarchySyncServer(t, &requests, bodies)
+
+	plan, err := service.Sync(context.Background(), desiredSyncHierarchy(), HierarchySyncOptions{AllowElementTypeChanges: true})
+	if err != nil {
+		t.Fatalf("Sync() failed: %v", err)
+	}
+
+	want := &HierarchyChangePlan{
+		ElementsToAdd:      []models.Element{{Name: "C", Type: models.ElementTypeNumeric}},
+		ElementsToDelete:   []string{"Old"},
+		ElementTypeChanges: []models.Element{{Name: "X", Type: models.ElementTypeConsolidated}},
+		EdgesToAdd:         []models.Edge{{ParentName: "Total", ComponentName: "C", Weight: 1}, {ParentName: "X", ComponentName: "C", Weight: 1}},
+		EdgesToRemove:      []models.Edge{{ParentName: "Total", ComponentName: "Old", Weight: 1}},
+		EdgeWeightChanges:  []models.Edge{{ParentName: "Total", ComponentName: "B", Weight: 2}},
+		AttributesToAdd:    []models.ElementAttribute{{Name: "Color", AttributeType: models.AttributeTypeString}},
+		AttributesToDelete: []string{"Old Attr"},
+		Warnings:           []string{"element X changes type from Numeric to Consolidated and loses its data and attribute values"},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("plan = %+v\nwant %+v", plan, want)
+	}
+
+	wantRequests := []string{
+		"POST /Dimensions('Region')/Hierarchies('Region')/ElementAttributes",
+		"DELETE /Dimensions('Region')/Hierarchies('Region')/Elements('Total')/Edges(ParentName='Total',ComponentName='B')",
+		"DELETE /Dimensions('Region')/Hierarchies('Region')/Elements('Old')",
+		"DELETE /Dimensions('Region')/Hierarchies('Region')/Elements('X')",
+		"POST /Dimensions('Region')/Hierarchies('Region')/Elements",
+		"POST /Dimensions('Region')/Hierarchies('Region')/Edges",
+		"DELETE /Dimensions('}ElementAttributes_Region')/Hierarchies('}ElementAttributes_Region')/Elements('Old Attr')",
+	}
+	if strings.Join(requests, "\n") != strings.Join(wantRequests, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(wantRequests, "\n"))
+	}
+
+	elements := bodies["POST /Dimensions('Region')/Hierarchies('Region')/Elements"].([]interface{})
+	if len(elements) != 2 {
+		t.Errorf("added elements = %v", elements)
+	}
+	edges := bodies["POST /Dimensions('Region')/Hierarchies('Region')/Edges"].([]interface{})
+	if len(edges) != 3 {
+		t.Errorf("added edges = %v", edges)
+	}
+}
+
+func TestHierarchyServiceSyncDryRunAndKeep(t *testing.T) {
+	var requests []string
+	service := newHierarchySyncServer(t, &requests, make(map[string]interface{}))
+
+	plan, err := service.Sync(context.Background(), desiredSyncHierarchy(), HierarchySyncOptions{DryRun: true, KeepElements: true, KeepAttributes: true})
+	if err != nil {
+		t.Fatalf("Sync() failed: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("dry run sent changes: %v", requests)
+	}
+	if len(plan.ElementsToDelete) != 0 || len(plan.EdgesToRemove) != 0 || len(plan.AttributesToDelete) != 0 {
+		t.Errorf("plan deletes kept objects: %+v", plan)
+	}
+	if plan.IsEmpty() {
+		t.Error("IsEmpty() = true")
+	}
+
+	invalid := desiredSyncHierarchy()
+	invalid.AddEdge("Total", "Missing", 1)
+	if _, err := service.Sync(context.Background(), invalid, HierarchySyncOptions{DryRun: true}); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("Sync() with unknown edge element error = %v", err)
+	}
+
+	duplicate := desiredSyncHierarchy()
+	duplicate.AddElement(models.Element{Name: "T otal", Type: models.ElementTypeConsolidated})
+	if _, err := service.Sync(context.Background(), duplicate, HierarchySyncOptions{DryRun: true}); err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("Sync() with duplicate element error = %v", err)
+	}
+}
+
+func TestHierarchyServiceSyncAttributeTypeChange(t *testing.T) {
+	var requests []string
+	service := newHierarchySyncServer(t, &requests, make(map[string]interface{}))
+
+	desired := desiredSyncHierarchy()
+	desired.AddElementAttribute(models.ElementAttribute{Name: "Old Attr", AttributeType: models.AttributeTypeNumeric})
+
+	plan, err := service.Sync(context.Background(), desired, HierarchySyncOptions{KeepElements: true, AllowElementTypeChanges: true})
+	if err == nil || !strings.Contains(err.Error(), "lose their values") {
+		t.Fatalf("Sync() error = %v, want attribute type change error", err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("refused sync sent changes: %v", requests)
+	}
+	wantWarnings := []string{"element X changes type from Numeric to Consolidated and loses its data and attribute values", "attribute Old Attr changes type from String to Numeric and loses its values"}
+	if !reflect.DeepEqual(plan.Warnings, wantWarnings) {
+		t.Errorf("Warnings = %v, want %v", plan.Warnings, wantWarnings)
+	}
+
+	if _, err := service.Sync(context.Background(), desired, HierarchySyncOptions{KeepElements: true, AllowElementTypeChanges: true, AllowAttributeTypeChanges: true}); err != nil {
+		t.Fatalf("Sync() with AllowAttributeTypeChanges failed: %v", err)
+	}
+	recreate := []string{
+		"DELETE /Dimensions('}ElementAttributes_Region')/Hierarchies('}ElementAttributes_Region')/Elements('Old Attr')",
+		"POST /Dimensions('Region')/Hierarchies('Region')/ElementAttributes",
+	}
+	if got := requests[len(requests)-2:]; !reflect.DeepEqual(got, recreate) {
+		t.Errorf("last requests = %v, want %v", got, recreate)
+	}
+}
+
+func TestHierarchyServiceSyncElementTypeChange(t *testing.T) {
+	var requests []string
+	service := newHierarchySyncServer(t, &requests, make(map[string]interface{}))
+
+	plan, err := service.Sync(context.Background(), desiredSyncHierarchy(), HierarchySyncOptions{})
+	if err == nil || !strings.Contains(err.Error(), "change the type of 1 elements") {
+		t.Fatalf("Sync() error = %v, want element type change error", err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("refused sync sent changes: %v", requests)
+	}
+	want := []string{"element X changes type from Numeric to Consolidated and loses its data and attribute values"}
+	if !reflect.DeepEqual(plan.Warnings, want) {
+		t.Errorf("Warnings = %v, want %v", plan.Warnings, want)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net/url"
 	"strings"
 
 	"github.com/go-gota/gota/dataframe"
@@ -41,7 +40,7 @@ func (js *JobService) Cancel(ctx context.Context, jobID interface{}) error {
 		return err
 	}
 
-	endpoint := fmt.Sprintf("/Jobs('%s')/tm1.Cancel", url.PathEscape(fmt.Sprintf("%v", jobID)))
+	endpoint := fmt.Sprintf("/Jobs('%s')/tm1.Cancel", escapeODataKey(fmt.Sprintf("%v", jobID)))
 	resp, err := js.rest.Post(ctx, endpoint, strings.NewReader(""))
 	if err != nil {
 		return err
@@ -0,0 +1,280 @@
+package tm1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+// MDXMember identifies an element of a dimension hierarchy in MDX.
+type MDXMember struct {
+	Dimension string
+	Hierarchy string // Defaults to the dimension name
+	Element   string
+}
+
+// NewMDXMember creates a member reference. An empty hierarchy refers to the default hierarchy.
+func NewMDXMember(dimension, hierarchy, element string) MDXMember {
+	return MDXMember{Dimension: dimension, Hierarchy: hierarchy, Element: element}
+}
+
+// UniqueName returns the escaped unique name [dimension].[hierarchy].[element].
+func (m MDXMember) UniqueName() string {
+	return MDXHierarchy(m.Dimension, m.Hierarchy) + "." + models.QuoteMDXName(m.Element)
+}
+
+// MDXHierarchy returns the escaped unique name [dimension].[hierarchy] of a hierarchy.
+// An empty hierarchy refers to the default hierarchy.
+func MDXHierarchy(dimension, hierarchy string) string {
+	if hierarchy == "" {
+		hierarchy = dimension
+	}
+	return models.QuoteMDXName(dimension) + "." + models.QuoteMDXName(hierarchy)
+}
+
+// MDXTuple returns a tuple expression for the given members.
+func MDXTuple(members ...MDXMember) string {
+	names := make([]string, len(members))
+	for i, member := range members {
+		names[i] = member.UniqueName()
+	}
+	return "(" + strings.Join(names, ",") + ")"
+}
+
+// MDXSet is a composable MDX set expression. Its MDX method returns a string that can be
+// passed to ElementService.ExecuteSetMDX or used as an axis of an MDXQuery.
+type MDXSet struct {
+	expression string
+}
+
+// MDX returns the set expression.
+func (s MDXSet) MDX() string {
+	return s.expression
+}
+
+// String implements fmt.Stringer.
+func (s MDXSet) String() string {
+	return s.expression
+}
+
+// RawSet wraps an existing MDX set expression.
+func RawSet(expression string) MDXSet {
+	return MDXSet{expression: expression}
+}
+
+// MembersSet returns the set {member1,member2,...}.
+func MembersSet(members ...MDXMember) MDXSet {
+	names := make([]string, len(members))
+	for i, member := range members {
+		names[i] = member.UniqueName()
+	}
+	return MDXSet{expression: "{" + strings.Join(names, ",") + "}"}
+}
+
+// TuplesSet returns a set of tuples, each tuple given as a list of members.
+func TuplesSet(tuples ...[]MDXMember) MDXSet {
+	expressions := make([]string, len(tuples))
+	for i, tuple := range tuples {
+		expressions[i] = MDXTuple(tuple...)
+	}
+	return MDXSet{expression: "{" + strings.Join(expressions, ",") + "}"}
+}
+
+// NamedSet references a set declared with MDXQuery.WithSet.
+func NamedSet(name string) MDXSet {
+	return MDXSet{expression: models.QuoteMDXName(name)}
+}
+
+// TM1SubsetAll returns all elements of a hierarchy.
+func TM1SubsetAll(dimension, hierarchy string) MDXSet {
+	return MDXSet{expression: "{TM1SUBSETALL(" + MDXHierarchy(dimension, hierarchy) + ")}"}
+}
+
+// TM1SubsetToSet returns the elements of a registered subset.
+func TM1SubsetToSet(dimension, hierarchy, subsetName string) MDXSet {
+	return MDXSet{expression: models.TM1SubsetToSetMDX(dimension, hierarchy, subsetName)}
+}
+
+// Descendants returns a member and all of its descendants.
+func Descendants(member MDXMember) MDXSet {
+	return MDXSet{expression: "{DESCENDANTS(" + member.UniqueName() + ")}"}
+}
+
+// CrossJoin returns the cartesian product of the given sets.
+func CrossJoin(sets ...MDXSet) MDXSet {
+	expressions := make([]string, len(sets))
+	for i, set := range sets {
+		expressions[i] = set.expression
+	}
+	return MDXSet{expression: "{" + strings.Join(expressions, " * ") + "}"}
+}
+
+// Union returns the union of the given sets.
+func Union(sets ...MDXSet) MDXSet {
+	expressions := make([]string, len(sets))
+	for i, set := range sets {
+		expressions[i] = set.expression
+	}
+	return MDXSet{expression: "{" + strings.Join(expressions, ",") + "}"}
+}
+
+// FilterByLevel keeps the elements on the given levels, where 0 is the leaf level.
+func (s MDXSet) FilterByLevel(levels ...int) MDXSet {
+	args := make([]string, 0, len(levels)+1)
+	args = append(args, s.expression)
+	for _, level := range levels {
+		args = append(args, strconv.Itoa(level))
+	}
+	return MDXSet{expression: "{TM1FILTERBYLEVEL(" + strings.Join(args, ",") + ")}"}
+}
+
+// Filter keeps the tuples for which the MDX condition is true.
+func (s MDXSet) Filter(condition string) MDXSet {
+	return MDXSet{expression: "{FILTER(" + s.expression + "," + condition + ")}"}
+}
+
+// FilterByAttribute keeps the elements whose string attribute equals value.
+func (s MDXSet) FilterByAttribute(dimension, hierarchy, attribute, value string) MDXSet {
+	condition := fmt.Sprintf("%s.CurrentMember.Properties(%s) = %s",
+		MDXHierarchy(dimension, hierarchy), quoteMDXString(attribute), quoteMDXString(value))
+	return s.Filter(condition)
+}
+
+// Order sorts the set by an MDX expression. Hierarchies are broken (BASC/BDESC) so the
+// result is ordered across parents.
+func (s MDXSet) Order(expression string, descending bool) MDXSet {
+	direction := "BASC"
+	if descending {
+		direction = "BDESC"
+	}
+	return MDXSet{expression: "{ORDER(" + s.expression + "," + expression + "," + direction + ")}"}
+}
+
+// Head returns the first count tuples of the set.
+func (s MDXSet) Head(count int) MDXSet {
+	return MDXSet{expression: "{HEAD(" + s.expression + "," + strconv.Itoa(count) + ")}"}
+}
+
+// MDXQuery builds an MDX SELECT statement.
+type MDXQuery struct {
+	cube            string
+	with            []string
+	columns         *MDXSet
+	rows            *MDXSet
+	nonEmptyColumns bool
+	nonEmptyRows    bool
+	where           []MDXMember
+}
+
+// NewMDXQuery creates a query against the given cube.
+func NewMDXQuery(cube string) *MDXQuery {
+	return &MDXQuery{cube: cube}
+}
+
+// WithMember declares a calculated member [dimension].[hierarchy].[element] AS expression.
+func (q *MDXQuery) WithMember(member MDXMember, expression string) *MDXQuery {
+	q.with = append(q.with, "MEMBER "+member.UniqueName()+" AS "+expression)
+	return q
+}
+
+// WithSet declares a named set that can be referenced with NamedSet.
+func (q *MDXQuery) WithSet(name string, set MDXSet) *MDXQuery {
+	q.with = append(q.with, "SET "+models.QuoteMDXName(name)+" AS "+set.expression)
+	return q
+}
+
+// Columns sets the columns axis.
+func (q *MDXQuery) Columns(set MDXSet) *MDXQuery {
+	q.columns = &set
+	return q
+}
+
+// Rows sets the rows axis.
+func (q *MDXQuery) Rows(set MDXSet) *MDXQuery {
+	q.rows = &set
+	return q
+}
+
+// NonEmptyColumns suppresses empty columns.
+func (q *MDXQuery) NonEmptyColumns() *MDXQuery {
+	q.nonEmptyColumns = true
+	return q
+}
+
+// NonEmptyRows suppresses empty rows.
+func (q *MDXQuery) NonEmptyRows() *MDXQuery {
+	q.nonEmptyRows = true
+	return q
+}
+
+// NonEmpty suppresses empty rows and columns.
+func (q *MDXQuery) NonEmpty() *MDXQuery {
+	return q.NonEmptyColumns().NonEmptyRows()
+}
+
+// Where adds members to the slicer.
+func (q *MDXQuery) Where(members ...MDXMember) *MDXQuery {
+	q.where = append(q.where, members...)
+	return q
+}
+
+// Build returns the MDX statement, to be passed to CellService.ExecuteMDX.
+func (q *MDXQuery) Build() (string, error) {
+	if q.cube == "" {
+		return "", fmt.Errorf("mdx query must have a cube")
+	}
+	if q.columns == nil {
+		return "", fmt.Errorf("mdx query must have a columns axis")
+	}
+
+	var b strings.Builder
+	if len(q.with) > 0 {
+		b.WriteString("WITH ")
+		b.WriteString(strings.Join(q.with, " "))
+		b.WriteString(" ")
+	}
+
+	b.WriteString("SELECT ")
+	b.WriteString(axisExpression(*q.columns, q.nonEmptyColumns))
+	b.WriteString(" ON COLUMNS")
+	if q.rows != nil {
+		b.WriteString(", ")
+		b.WriteString(axisExpression(*q.rows, q.nonEmptyRows))
+		b.WriteString(" ON ROWS")
+	}
+
+	b.WriteString(" FROM ")
+	b.WriteString(models.QuoteMDXName(q.cube))
+
+	if len(q.where) > 0 {
+		b.WriteString(" WHERE ")
+		b.WriteString(MDXTuple(q.where...))
+	}
+
+	return b.String(), nil
+}
+
+// MDX returns the MDX statement, or an empty string if the query is incomplete.
+func (q *MDXQuery) MDX() string {
+	mdx, _ := q.Build()
+	return mdx
+}
+
+// String implements fmt.Stringer.
+func (q *MDXQuery) String() string {
+	return q.MDX()
+}
+
+func axisExpression(set MDXSet, nonEmpty bool) string {
+	if nonEmpty {
+		return "NON EMPTY " + set.expression
+	}
+	return set.expression
+}
+
+// quoteMDXString returns an MDX string literal, doubling embedded quotes.
+func quoteMDXString(value string) string {
+	return models.QuoteMDXString(value)
+}
@@ -0,0 +1,66 @@
+package tm1
+
+import "testing"
+
+func TestMDXSetExpressions(t *testing.T) {
+	region := NewMDXMember("Region", "", "Europe")
+
+	tests := []struct {
+		name string
+		set  MDXSet
+		want string
+	}{
+		{name: "members", set: MembersSet(region, NewMDXMember("Region", "By Country", "a]b")), want: "{[Region].[Region].[Europe],[Region].[By Country].[a]]b]}"},
+		{name: "tuples", set: TuplesSet([]MDXMember{region, NewMDXMember("Year", "", "2024")}), want: "{([Region].[Region].[Europe],[Year].[Year].[2024])}"},
+		{name: "subset all", set: TM1SubsetAll("Region", ""), want: "{TM1SUBSETALL([Region].[Region])}"},
+		{name: "subset to set", set: TM1SubsetToSet("Region", "Alt", `My "Subset"`), want: `{TM1SUBSETTOSET([Region].[Alt],"My ""Subset""")}`},
+		{name: "leaf descendants", set: Descendants(region).FilterByLevel(0), want: "{TM1FILTERBYLEVEL({DESCENDANTS([Region].[Region].[Europe])},0)}"},
+		{name: "crossjoin", set: CrossJoin(TM1SubsetAll("Region", ""), MembersSet(NewMDXMember("Year", "", "2024"))), want: "{{TM1SUBSETALL([Region].[Region])} * {[Year].[Year].[2024]}}"},
+		{name: "filter by attribute", set: TM1SubsetAll("Region", "").FilterByAttribute("Region", "", "Currency", "EUR"), want: `{FILTER({TM1SUBSETALL([Region].[Region])},[Region].[Region].CurrentMember.Properties("Currency") = "EUR")}`},
+		{name: "order and head", set: TM1SubsetAll("Region", "").Order("[Sales].([Measure].[Measure].[Units])", true).Head(10), want: "{HEAD({ORDER({TM1SUBSETALL([Region].[Region])},[Sales].([Measure].[Measure].[Units]),BDESC)},10)}"},
+		{name: "union", set: Union(MembersSet(region), NamedSet("Top")), want: "{{[Region].[Region].[Europe]},[Top]}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.set.MDX(); got != tt.want {
+				t.Errorf("MDX() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMDXQueryBuild(t *testing.T) {
+	query := NewMDXQuery("Sales]Cube").
+		WithMember(NewMDXMember("Measure", "", "Margin"), "[Measure].[Measure].[Revenue] - [Measure].[Measure].[Cost]").
+		WithSet("Leaves", TM1SubsetAll("Region", "").FilterByLevel(0)).
+		Columns(MembersSet(NewMDXMember("Measure", "", "Margin"))).
+		Rows(CrossJoin(NamedSet("Leaves"), TM1SubsetAll("Year", ""))).
+		NonEmptyRows().
+		Where(NewMDXMember("Version", "", "Actual"), NewMDXMember("Currency", "", "EUR"))
+
+	mdx, err := query.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "WITH MEMBER [Measure].[Measure].[Margin] AS [Measure].[Measure].[Revenue] - [Measure].[Measure].[Cost] " +
+		"SET [Leaves] AS {TM1FILTERBYLEVEL({TM1SUBSETALL([Region].[Region])},0)} " +
+		"SELECT {[Measure].[Measure].[Margin]} ON COLUMNS, NON EMPTY {[Leaves] * {TM1SUBSETALL([Year].[Year])}} ON ROWS " +
+		"FROM [Sales]]Cube] WHERE ([Version].[Version].[Actual],[Currency].[Currency].[EUR])"
+	if mdx != want {
+		t.Fatalf("Build() =\n%q\nwant\n%q", mdx, want)
+	}
+	if query.String() != want {
+		t.Fatal("String() does not match Build()")
+	}
+}
+
+func TestMDXQueryBuildValidation(t *testing.T) {
+	if _, err := NewMDXQuery("").Columns(TM1SubsetAll("Region", "")).Build(); err == nil {
+		t.Error("Build() without cube expected error")
+	}
+	if _, err := NewMDXQuery("Sales").Build(); err == nil {
+		t.Error("Build() without columns expected error")
+	}
+}
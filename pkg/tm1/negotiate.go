@@ -0,0 +1,338 @@
+package tm1
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/crypto"
+	"github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/flags"
+	"github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/jcmturner/gokrb5/v8/types"
+)
+
+// NegotiateTokenSource produces SPNEGO tokens for a service principal name such as
+// "HTTP/tm1.example.com".
+type NegotiateTokenSource interface {
+	NegotiateToken(ctx context.Context, spn string) ([]byte, error)
+}
+
+// NegotiateVerifier is implemented by token sources that verify the mutual
+// authentication token the server returns in its WWW-Authenticate header. token is nil
+// when the server did not return one.
+type NegotiateVerifier interface {
+	VerifyNegotiateToken(ctx context.Context, spn string, token []byte) error
+}
+
+// NegotiateAuth provides HTTP Negotiate (SPNEGO/Kerberos) authentication as used by
+// TM1 with IntegratedSecurityMode 2 or 3.
+//
+// RestService only sends a token on requests without a TM1 session, and answers a
+// Negotiate challenge on a session request by negotiating a new session. When Source
+// implements NegotiateVerifier the server's response token is verified.
+type NegotiateAuth struct {
+	Source  NegotiateTokenSource
+	Service string // Service part of the SPN. Default: "HTTP"
+	Host    string // Host part of the SPN. Default: host of the request URL
+}
+
+// Apply implements AuthProvider.
+func (n NegotiateAuth) Apply(req *http.Request) error {
+	if n.Source == nil {
+		return errors.New("tm1: negotiate token source is nil")
+	}
+	token, err := n.Source.NegotiateToken(req.Context(), n.spn(req))
+	if err != nil {
+		return fmt.Errorf("negotiate token: %w", err)
+	}
+	req.Header.Set("Authorization", "Negotiate "+base64.StdEncoding.EncodeToString(token))
+	return nil
+}
+
+// verifyResponse checks the server's mutual authentication token in a response to a
+// request that carried a Negotiate token.
+func (n NegotiateAuth) verifyResponse(resp *http.Response) error {
+	verifier, ok := n.Source.(NegotiateVerifier)
+	if !ok || resp.StatusCode == http.StatusUnauthorized || resp.Request == nil {
+		return nil
+	}
+	if scheme, _, _ := strings.Cut(resp.Request.Header.Get("Authorization"), " "); scheme != "Negotiate" {
+		return nil
+	}
+	token, err := negotiateChallengeToken(resp.Header)
+	if err != nil {
+		return err
+	}
+	if err := verifier.VerifyNegotiateToken(resp.Request.Context(), n.spn(resp.Request), token); err != nil {
+		return fmt.Errorf("negotiate mutual authentication: %w", err)
+	}
+	return nil
+}
+
+// negotiateChallenged reports whether the response carries a Negotiate challenge.
+func negotiateChallenged(resp *http.Response) bool {
+	for _, value := range resp.Header.Values("WWW-Authenticate") {
+		if scheme, _, _ := strings.Cut(strings.TrimSpace(value), " "); strings.EqualFold(scheme, "Negotiate") {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateChallengeToken returns the decoded token of a "WWW-Authenticate: Negotiate
+// <token>" header, or nil when the header carries none.
+func negotiateChallengeToken(header http.Header) ([]byte, error) {
+	for _, value := range header.Values("WWW-Authenticate") {
+		scheme, token, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if !strings.EqualFold(scheme, "Negotiate") || strings.TrimSpace(token) == "" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(token))
+		if err != nil {
+			return nil, fmt.Errorf("decode negotiate response token: %w", err)
+		}
+		return decoded, nil
+	}
+	return nil, nil
+}
+
+// spn returns the service principal name for the request.
+func (n NegotiateAuth) spn(req *http.Request) string {
+	service := strings.TrimSpace(n.Service)
+	if service == "" {
+		service = "HTTP"
+	}
+	host := strings.TrimSpace(n.Host)
+	if host == "" {
+		host = req.URL.Hostname()
+	}
+	return service + "/" + strings.ToLower(host)
+}
+
+// maxPendingAuthenticators bounds the authenticators kept per SPN for verifying the
+// server's AP-REP.
+const maxPendingAuthenticators = 16
+
+// kerberosTokenSource produces SPNEGO tokens with a gokrb5 client and verifies the
+// server's AP-REP against the authenticators it sent.
+type kerberosTokenSource struct {
+	client *client.Client
+
+	mu      sync.Mutex
+	pending map[string][]kerberosAuthenticator
+}
+
+// kerberosAuthenticator identifies an AP-REQ awaiting the server's AP-REP.
+type kerberosAuthenticator struct {
+	key   types.EncryptionKey
+	ctime time.Time
+	cusec int
+}
+
+// NegotiateToken implements NegotiateTokenSource. It logs in on first use and obtains a
+// service ticket for spn, which the client caches until it expires. The token requests
+// mutual authentication.
+func (k *kerberosTokenSource) NegotiateToken(ctx context.Context, spn string) ([]byte, error) {
+	if err := k.client.AffirmLogin(); err != nil {
+		return nil, fmt.Errorf("kerberos login: %w", err)
+	}
+	ticket, key, err := k.client.GetServiceTicket(spn)
+	if err != nil {
+		return nil, fmt.Errorf("kerberos service ticket for %s: %w", spn, err)
+	}
+	gssFlags := []int{gssapi.ContextFlagInteg, gssapi.ContextFlagConf, gssapi.ContextFlagMutual}
+	mechToken, err := spnego.NewKRB5TokenAPREQ(k.client, ticket, key, gssFlags, []int{flags.APOptionMutualRequired})
+	if err != nil {
+		return nil, fmt.Errorf("kerberos AP-REQ for %s: %w", spn, err)
+	}
+	mechTokenBytes, err := mechToken.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("kerberos AP-REQ for %s: %w", spn, err)
+	}
+	if err := mechToken.APReq.DecryptAuthenticator(key); err != nil {
+		return nil, fmt.Errorf("kerberos AP-REQ for %s: %w", spn, err)
+	}
+	k.remember(spn, kerberosAuthenticator{key: key, ctime: mechToken.APReq.Authenticator.CTime, cusec: mechToken.APReq.Authenticator.Cusec})
+
+	token := spnego.SPNEGOToken{Init: true}
+	token.NegTokenInit.MechTypes = append(token.NegTokenInit.MechTypes, gssapi.OIDKRB5.OID())
+	token.NegTokenInit.MechTokenBytes = mechTokenBytes
+	return token.Marshal()
+}
+
+// VerifyNegotiateToken implements NegotiateVerifier. The token must hold an AP-REP that
+// decrypts with the session key of a pending AP-REQ for spn and echoes its timestamp.
+func (k *kerberosTokenSource) VerifyNegotiateToken(ctx context.Context, spn string, token []byte) error {
+	if len(token) == 0 {
+		return errors.New("server did not return a mutual authentication token")
+	}
+	mechToken, err := krb5ResponseToken(token)
+	if err != nil {
+		return err
+	}
+	if mechToken.IsKRBError() {
+		return fmt.Errorf("server returned a Kerberos error: %s", mechToken.KRBError.Error())
+	}
+	if !mechToken.IsAPRep() {
+		return errors.New("server token is not an AP-REP")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for i, pending := range k.pending[spn] {
+		encPart, err := crypto.DecryptEncPart(mechToken.APRep.EncPart, pending.key, keyusage.AP_REP_ENCPART)
+		if err != nil {
+			continue
+		}
+		var repPart messages.EncAPRepPart
+		if err := repPart.Unmarshal(encPart); err != nil {
+			return fmt.Errorf("decode AP-REP: %w", err)
+		}
+		if !repPart.CTime.Equal(pending.ctime) || repPart.Cusec != pending.cusec {
+			continue
+		}
+		k.pending[spn] = append(k.pending[spn][:i], k.pending[spn][i+1:]...)
+		return nil
+	}
+	return fmt.Errorf("AP-REP for %s does not match a sent authenticator", spn)
+}
+
+// remember records an authenticator sent to spn, dropping the oldest beyond
+// maxPendingAuthenticators.
+func (k *kerberosTokenSource) remember(spn string, authenticator kerberosAuthenticator) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.pending == nil {
+		k.pending = make(map[string][]kerberosAuthenticator)
+	}
+	pending := append(k.pending[spn], authenticator)
+	if len(pending) > maxPendingAuthenticators {
+		pending = pending[len(pending)-maxPendingAuthenticators:]
+	}
+	k.pending[spn] = pending
+}
+
+// krb5ResponseToken extracts the Kerberos mechanism token from a SPNEGO NegTokenResp, or
+// parses token as a bare Kerberos token.
+func krb5ResponseToken(token []byte) (spnego.KRB5Token, error) {
+	var mechToken spnego.KRB5Token
+	var resp spnego.NegTokenResp
+	if err := resp.Unmarshal(token); err == nil {
+		if resp.State() == spnego.NegStateReject {
+			return mechToken, errors.New("server rejected the negotiation")
+		}
+		token = resp.ResponseToken
+	}
+	if err := mechToken.Unmarshal(token); err != nil {
+		return mechToken, fmt.Errorf("decode server token: %w", err)
+	}
+	return mechToken, nil
+}
+
+// newKerberosTokenSource creates a Kerberos client for integrated login. With
+// IntegratedLoginKeytab the client logs in as User using the keytab; otherwise it uses
+// the tickets of the credential cache created by kinit. Credential delegation is not
+// supported, so IntegratedLoginDelegate is rejected.
+func newKerberosTokenSource(cfg Config) (NegotiateTokenSource, error) {
+	if cfg.IntegratedLoginDelegate {
+		return nil, errors.New("IntegratedLoginDelegate is not supported: Kerberos credentials cannot be delegated")
+	}
+
+	krbConfig, err := loadKrb5Config(cfg.IntegratedLoginKrb5Conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.IntegratedLoginKeytab != "" {
+		kt, err := keytab.Load(cfg.IntegratedLoginKeytab)
+		if err != nil {
+			return nil, fmt.Errorf("load keytab: %w", err)
+		}
+		user, realm, err := integratedLoginPrincipal(cfg, krbConfig.LibDefaults.DefaultRealm, kt)
+		if err != nil {
+			return nil, err
+		}
+		return &kerberosTokenSource{client: client.NewWithKeytab(user, realm, kt, krbConfig, client.DisablePAFXFAST(true))}, nil
+	}
+
+	ccachePath := cfg.IntegratedLoginCCache
+	if ccachePath == "" {
+		ccachePath = os.Getenv("KRB5CCNAME")
+	}
+	if ccachePath == "" {
+		ccachePath = fmt.Sprintf("/tmp/krb5cc_%d", os.Getuid())
+	}
+	ccache, err := credentials.LoadCCache(strings.TrimPrefix(ccachePath, "FILE:"))
+	if err != nil {
+		return nil, fmt.Errorf("load credential cache: %w", err)
+	}
+	krbClient, err := client.NewFromCCache(ccache, krbConfig, client.DisablePAFXFAST(true))
+	if err != nil {
+		return nil, fmt.Errorf("load credential cache: %w", err)
+	}
+	return &kerberosTokenSource{client: krbClient}, nil
+}
+
+// loadKrb5Config reads krb5.conf from path, KRB5_CONFIG or /etc/krb5.conf. A missing file
+// yields a configuration that locates KDCs through DNS SRV records.
+func loadKrb5Config(path string) (*config.Config, error) {
+	if path == "" {
+		path = os.Getenv("KRB5_CONFIG")
+	}
+	if path == "" {
+		path = "/etc/krb5.conf"
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		krbConfig := config.New()
+		krbConfig.LibDefaults.DNSLookupKDC = true
+		return krbConfig, nil
+	}
+	krbConfig, err := config.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("load krb5.conf: %w", err)
+	}
+	return krbConfig, nil
+}
+
+// integratedLoginPrincipal determines the client principal for keytab logins. User may be
+// given as "user", "user@REALM" or "DOMAIN\user"; without a user the first keytab
+// principal is used. IntegratedLoginDomain, unless "." for a local account, names the realm.
+func integratedLoginPrincipal(cfg Config, defaultRealm string, kt *keytab.Keytab) (string, string, error) {
+	user := strings.TrimSpace(cfg.User)
+	if user == "" {
+		if len(kt.Entries) == 0 {
+			return "", "", errors.New("keytab contains no principals")
+		}
+		principal := kt.Entries[0].Principal
+		return strings.Join(principal.Components, "/"), principal.Realm, nil
+	}
+
+	realm := defaultRealm
+	if domain := strings.TrimSpace(cfg.IntegratedLoginDomain); domain != "" && domain != "." {
+		realm = strings.ToUpper(domain)
+	}
+	if domain, name, found := strings.Cut(user, `\`); found {
+		realm, user = strings.ToUpper(domain), name
+	}
+	if name, userRealm, found := strings.Cut(user, "@"); found {
+		realm, user = userRealm, name
+	}
+	if realm == "" {
+		return "", "", fmt.Errorf("no Kerberos realm for user %s: set IntegratedLoginDomain or default_realm in krb5.conf", user)
+	}
+	return user, realm, nil
+}
@@ -0,0 +1,347 @@
+package tm1
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+)
+
+// fakeNegotiateSource returns "token:<spn>" and records the requested SPNs.
+type fakeNegotiateSource struct {
+	mu   sync.Mutex
+	spns []string
+	err  error
+}
+
+func (f *fakeNegotiateSource) NegotiateToken(ctx context.Context, spn string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.spns = append(f.spns, spn)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte("token:" + spn), nil
+}
+
+// newNegotiateServer emulates TM1 with integrated security: requests without a session
+// are challenged until they carry a valid Negotiate token, which establishes a session.
+func newNegotiateServer(t *testing.T, wantToken string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("TM1SessionId"); err == nil && cookie.Value == "negotiated" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		scheme, value, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		token, err := base64.StdEncoding.DecodeString(value)
+		if scheme != "Negotiate" || err != nil || string(token) != wantToken {
+			w.Header().Set("WWW-Authenticate", "Negotiate")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "TM1SessionId", Value: "negotiated", Path: "/"})
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNegotiateAuthHandshake(t *testing.T) {
+	server := newNegotiateServer(t, "token:HTTP/127.0.0.1")
+	source := &fakeNegotiateSource{}
+
+	rs, err := NewRestService(Config{Address: "localhost", Port: 8882}, WithAuthProvider(NegotiateAuth{Source: source}))
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	resp, err := rs.Get(context.Background(), "/Configuration/ProductVersion/$value")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if rs.SessionID() != "negotiated" {
+		t.Errorf("SessionID() = %q, want negotiated", rs.SessionID())
+	}
+	if len(source.spns) != 1 || source.spns[0] != "HTTP/127.0.0.1" {
+		t.Errorf("requested SPNs = %v", source.spns)
+	}
+}
+
+func TestNegotiateAuthRejected(t *testing.T) {
+	server := newNegotiateServer(t, "token:HTTP/tm1.example.com")
+
+	rs, err := NewRestService(Config{Address: "localhost", Port: 8882}, WithAuthProvider(NegotiateAuth{Source: &fakeNegotiateSource{}}))
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	_, err = rs.Get(context.Background(), "/Configuration/ProductVersion/$value")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Get() error = %v, want 401", err)
+	}
+}
+
+// fakeMutualSource additionally expects the server token "mutual:<spn>".
+type fakeMutualSource struct {
+	fakeNegotiateSource
+	verified int
+}
+
+func (f *fakeMutualSource) VerifyNegotiateToken(ctx context.Context, spn string, token []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if string(token) != "mutual:"+spn {
+		return errors.New("unexpected server token")
+	}
+	f.verified++
+	return nil
+}
+
+func TestNegotiateAuthSessionAndChallenge(t *testing.T) {
+	var mu sync.Mutex
+	var authorizations []string
+	sessions := 0
+	current := ""
+	mutualToken := "mutual:HTTP/127.0.0.1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if cookie, err := r.Cookie("TM1SessionId"); err == nil && cookie.Value == current {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if r.Header.Get("Authorization") == "" {
+			w.Header().Set("WWW-Authenticate", "Negotiate")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		sessions++
+		current = fmt.Sprintf("session%d", sessions)
+		http.SetCookie(w, &http.Cookie{Name: "TM1SessionId", Value: current, Path: "/"})
+		w.Header().Set("WWW-Authenticate", "Negotiate "+base64.StdEncoding.EncodeToString([]byte(mutualToken)))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	source := &fakeMutualSource{}
+	rs, err := NewRestService(Config{Address: "localhost", Port: 8882}, WithAuthProvider(NegotiateAuth{Source: source}), WithReConnect(false, false))
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	get := func() {
+		t.Helper()
+		resp, err := rs.Get(context.Background(), "/Configuration/ProductVersion/$value")
+		if err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	get()
+	get()
+	mu.Lock()
+	current = "expired"
+	mu.Unlock()
+	get()
+
+	if rs.SessionID() != "session2" {
+		t.Errorf("SessionID() = %q, want session2", rs.SessionID())
+	}
+	negotiated := 0
+	for _, authorization := range authorizations {
+		if strings.HasPrefix(authorization, "Negotiate ") {
+			negotiated++
+		}
+	}
+	if len(authorizations) != 4 || negotiated != 2 || authorizations[1] != "" || authorizations[2] != "" {
+		t.Errorf("Authorization headers = %q, want tokens only without a session", authorizations)
+	}
+	if source.verified != 2 {
+		t.Errorf("verified server tokens = %d, want 2", source.verified)
+	}
+
+	mu.Lock()
+	mutualToken = "mutual:HTTP/impostor"
+	current = "expired"
+	mu.Unlock()
+	if _, err := rs.Get(context.Background(), "/Configuration/ProductVersion/$value"); err == nil || !strings.Contains(err.Error(), "mutual authentication") {
+		t.Errorf("Get() error = %v, want mutual authentication failure", err)
+	}
+}
+
+func TestKerberosTokenSourceVerifyRejectsInvalidTokens(t *testing.T) {
+	source := &kerberosTokenSource{}
+	if err := source.VerifyNegotiateToken(context.Background(), "HTTP/tm1", nil); err == nil || !strings.Contains(err.Error(), "did not return") {
+		t.Errorf("VerifyNegotiateToken() without token error = %v", err)
+	}
+	if err := source.VerifyNegotiateToken(context.Background(), "HTTP/tm1", []byte("garbage")); err == nil {
+		t.Error("VerifyNegotiateToken() with garbage succeeded")
+	}
+}
+
+func TestNegotiateAuthApply(t *testing.T) {
+	source := &fakeNegotiateSource{}
+	auth := NegotiateAuth{Source: source, Service: "tm1", Host: "TM1.Example.com"}
+
+	req := httptest.NewRequest(http.MethodGet, "https://10.0.0.1:12354/api/v1/Cubes", nil)
+	if err := auth.Apply(req); err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	want := "Negotiate " + base64.StdEncoding.EncodeToString([]byte("token:tm1/tm1.example.com"))
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+
+	source.err = errors.New("no ticket")
+	if err := auth.Apply(req); err == nil || !strings.Contains(err.Error(), "no ticket") {
+		t.Errorf("Apply() error = %v, want source error", err)
+	}
+	if err := (NegotiateAuth{}).Apply(req); err == nil {
+		t.Error("Apply() without source succeeded")
+	}
+}
+
+// writeTestKeytab writes a keytab holding an AES256 key for each principal.
+func writeTestKeytab(t *testing.T, principals ...string) string {
+	t.Helper()
+	kt := keytab.New()
+	for _, principal := range principals {
+		name, realm, _ := strings.Cut(principal, "@")
+		if err := kt.AddEntry(name, realm, "secret", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := kt.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "tm1.keytab")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRestServiceIntegratedLogin(t *testing.T) {
+	dir := t.TempDir()
+	krbConf := filepath.Join(dir, "krb5.conf")
+	if err := os.WriteFile(krbConf, []byte("[libdefaults]\n  default_realm = EXAMPLE.COM\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keytabPath := writeTestKeytab(t, "alice@EXAMPLE.COM")
+
+	cfg := Config{
+		Address:                 "tm1.example.com",
+		Port:                    12354,
+		IntegratedLogin:         true,
+		IntegratedLoginService:  "HTTP",
+		IntegratedLoginHost:     "tm1",
+		IntegratedLoginKeytab:   keytabPath,
+		IntegratedLoginKrb5Conf: krbConf,
+	}
+	rs, err := NewRestService(cfg)
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	auth, ok := rs.auth.(NegotiateAuth)
+	if !ok {
+		t.Fatalf("auth = %T, want NegotiateAuth", rs.auth)
+	}
+	if auth.Service != "HTTP" || auth.Host != "tm1" {
+		t.Errorf("auth = %+v", auth)
+	}
+	if rs.authMode != AuthModeWIA {
+		t.Errorf("authMode = %v, want AuthModeWIA", rs.authMode)
+	}
+
+	missing := cfg
+	missing.IntegratedLoginKeytab = ""
+	missing.IntegratedLoginCCache = filepath.Join(dir, "krb5cc_missing")
+	if _, err := NewRestService(missing); err == nil || !strings.Contains(err.Error(), "credential cache") {
+		t.Errorf("NewRestService() with missing ccache error = %v", err)
+	}
+
+	delegate := cfg
+	delegate.IntegratedLoginDelegate = true
+	if _, err := NewRestService(delegate); err == nil || !strings.Contains(err.Error(), "IntegratedLoginDelegate") {
+		t.Errorf("NewRestService() with delegation error = %v, want unsupported", err)
+	}
+}
+
+func TestIntegratedLoginPrincipal(t *testing.T) {
+	kt, err := keytab.Load(writeTestKeytab(t, "svc_tm1@CORP.EXAMPLE.COM"))
+	if err != nil {
+		t.Fatalf("keytab.Load() failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		user   string
+		domain string
+		want   string
+	}{
+		{name: "default realm", user: "alice", want: "alice@EXAMPLE.COM"},
+		{name: "local account", user: "alice", domain: ".", want: "alice@EXAMPLE.COM"},
+		{name: "domain", user: "alice", domain: "corp.example.com", want: "alice@CORP.EXAMPLE.COM"},
+		{name: "down-level logon name", user: `corp\alice`, want: "alice@CORP"},
+		{name: "user principal name", user: "alice@OTHER.COM", domain: "corp", want: "alice@OTHER.COM"},
+		{name: "keytab principal", want: "svc_tm1@CORP.EXAMPLE.COM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, realm, err := integratedLoginPrincipal(Config{User: tt.user, IntegratedLoginDomain: tt.domain}, "EXAMPLE.COM", kt)
+			if err != nil {
+				t.Fatalf("integratedLoginPrincipal() failed: %v", err)
+			}
+			if got := user + "@" + realm; got != tt.want {
+				t.Errorf("integratedLoginPrincipal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, _, err := integratedLoginPrincipal(Config{User: "alice"}, "", kt); err == nil {
+		t.Error("integratedLoginPrincipal() without realm succeeded")
+	}
+}
+
+func TestKerberosTokenSourceLoginFailure(t *testing.T) {
+	dir := t.TempDir()
+	krbConf := filepath.Join(dir, "krb5.conf")
+	conf := "[libdefaults]\n  default_realm = EXAMPLE.COM\n  udp_preference_limit = 1\n[realms]\n  EXAMPLE.COM = {\n    kdc = 127.0.0.1:1\n  }\n"
+	if err := os.WriteFile(krbConf, []byte(conf), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := newKerberosTokenSource(Config{
+		User:                    "alice",
+		IntegratedLoginKeytab:   writeTestKeytab(t, "alice@EXAMPLE.COM"),
+		IntegratedLoginKrb5Conf: krbConf,
+	})
+	if err != nil {
+		t.Fatalf("newKerberosTokenSource() failed: %v", err)
+	}
+	if _, err := source.NegotiateToken(context.Background(), "HTTP/tm1.example.com"); err == nil || !strings.Contains(err.Error(), "kerberos login") {
+		t.Errorf("NegotiateToken() error = %v, want login failure", err)
+	}
+}
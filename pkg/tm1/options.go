@@ -96,6 +96,27 @@ func WithAuthProvider(provider AuthProvider) RestOption {
 			return errors.New("tm1: auth provider cannot be nil")
 		}
 		rs.auth = provider
+		rs.authOverridden = true
+		return nil
+	}
+}
+
+// WithReConnect controls whether requests are replayed once after re-authenticating
+// when the TM1 session has timed out or the connection was dropped by the remote end.
+// Both behaviours are enabled by default.
+func WithReConnect(onSessionTimeout, onRemoteDisconnect bool) RestOption {
+	return func(rs *RestService) error {
+		rs.reConnectOnSessionTimeout = onSessionTimeout
+		rs.reConnectOnRemoteDisconnect = onRemoteDisconnect
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry requests that fail with transient errors.
+// Passing nil disables retries.
+func WithRetryPolicy(policy *RetryPolicy) RestOption {
+	return func(rs *RestService) error {
+		rs.retryPolicy = policy
 		return nil
 	}
 }
@@ -45,7 +45,7 @@ func (ps *ProcessService) Get(ctx context.Context, processName string) (*models.
 		"DataSource/subset,"+
 		"DataSource/jsonRootPointer,"+
 		"DataSource/jsonVariableMapping",
-		url.PathEscape(processName))
+		escapeODataKey(processName))
 
 	var process models.Process
 	err := ps.rest.JSON(ctx, "GET", endpoint, nil, &process)
@@ -265,7 +265,7 @@ func (ps *ProcessService) Update(ctx context.Context, process *models.Process) e
 		return fmt.Errorf("failed to marshal process: %w", err)
 	}
 
-	endpoint := fmt.Sprintf("/Processes('%s')", url.PathEscape(process.Name))
+	endpoint := fmt.Sprintf("/Processes('%s')", escapeODataKey(process.Name))
 	resp, err := ps.rest.Patch(ctx, endpoint, bytes.NewReader(bodyJSON))
 	if err != nil {
 		return err
@@ -296,7 +296,7 @@ func (ps *ProcessService) UpdateOrCreate(ctx context.Context, process *models.Pr
 
 // Delete deletes a process from TM1 Server
 func (ps *ProcessService) Delete(ctx context.Context, processName string) error {
-	endpoint := fmt.Sprintf("/Processes('%s')", url.PathEscape(processName))
+	endpoint := fmt.Sprintf("/Processes('%s')", escapeODataKey(processName))
 	resp, err := ps.rest.Delete(ctx, endpoint)
 	if err != nil {
 		return err
@@ -308,7 +308,7 @@ func (ps *ProcessService) Delete(ctx context.Context, processName string) error
 
 // Exists checks if a process exists on TM1 Server
 func (ps *ProcessService) Exists(ctx context.Context, processName string) (bool, error) {
-	endpoint := fmt.Sprintf("/Processes('%s')", url.PathEscape(processName))
+	endpoint := fmt.Sprintf("/Processes('%s')", escapeODataKey(processName))
 	resp, err := ps.rest.Get(ctx, endpoint)
 	if err != nil {
 		// Check if it's a 404 error
@@ -324,7 +324,7 @@ func (ps *ProcessService) Exists(ctx context.Context, processName string) (bool,
 
 // Compile compiles a process and returns syntax errors
 func (ps *ProcessService) Compile(ctx context.Context, processName string) ([]interface{}, error) {
-	endpoint := fmt.Sprintf("/Processes('%s')/tm1.Compile", url.PathEscape(processName))
+	endpoint := fmt.Sprintf("/Processes('%s')/tm1.Compile", escapeODataKey(processName))
 
 	var response struct {
 		Value []interface{} `json:"value"`
@@ -376,7 +376,7 @@ func (ps *ProcessService) CompileProcess(ctx context.Context, process *models.Pr
 
 // Execute executes a process on TM1 Server
 func (ps *ProcessService) Execute(ctx context.Context, processName string, parameters map[string]interface{}, timeout *time.Duration, cancelAtTimeout bool) error {
-	endpoint := fmt.Sprintf("/Processes('%s')/tm1.Execute", url.PathEscape(processName))
+	endpoint := fmt.Sprintf("/Processes('%s')/tm1.Execute", escapeODataKey(processName))
 
 	payload := map[string]interface{}{}
 	if len(parameters) > 0 {
@@ -411,7 +411,7 @@ func (ps *ProcessService) Execute(ctx context.Context, processName string, param
 
 // ExecuteWithReturn executes a process and returns execution status
 func (ps *ProcessService) ExecuteWithReturn(ctx context.Context, processName string, parameters map[string]interface{}, timeout *time.Duration, cancelAtTimeout bool) (bool, string, string, error) {
-	endpoint := fmt.Sprintf("/Processes('%s')/tm1.ExecuteWithReturn?$expand=*", url.PathEscape(processName))
+	endpoint := fmt.Sprintf("/Processes('%s')/tm1.ExecuteWithReturn?$expand=*", escapeODataKey(processName))
 
 	payload := map[string]interface{}{}
 	if len(parameters) > 0 {
@@ -563,7 +563,7 @@ func (ps *ProcessService) GetErrorLogFilenames(ctx context.Context, processName
 
 // GetErrorLogFileContent retrieves the content of an error log file
 func (ps *ProcessService) GetErrorLogFileContent(ctx context.Context, filename string) (string, error) {
-	endpoint := fmt.Sprintf("/ErrorLogFiles('%s')/Content", url.PathEscape(filename))
+	endpoint := fmt.Sprintf("/ErrorLogFiles('%s')/Content", escapeODataKey(filename))
 
 	resp, err := ps.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -581,7 +581,7 @@ func (ps *ProcessService) GetErrorLogFileContent(ctx context.Context, filename s
 
 // GetProcessErrorLogs gets all ProcessErrorLog entries for a process
 func (ps *ProcessService) GetProcessErrorLogs(ctx context.Context, processName string) ([]interface{}, error) {
-	endpoint := fmt.Sprintf("/Processes('%s')/ErrorLogs", url.PathEscape(processName))
+	endpoint := fmt.Sprintf("/Processes('%s')/ErrorLogs", escapeODataKey(processName))
 
 	var response struct {
 		Value []interface{} `json:"value"`
@@ -610,8 +610,8 @@ func (ps *ProcessService) GetLastMessageFromProcessErrorLog(ctx context.Context,
 	timestamp := lastLog["Timestamp"].(string)
 
 	endpoint := fmt.Sprintf("/Processes('%s')/ErrorLogs('%s')/Content",
-		url.PathEscape(processName),
-		url.PathEscape(timestamp))
+		escapeODataKey(processName),
+		escapeODataKey(timestamp))
 
 	resp, err := ps.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -630,7 +630,7 @@ func (ps *ProcessService) GetLastMessageFromProcessErrorLog(ctx context.Context,
 // DebugProcess starts a debug session for a process
 func (ps *ProcessService) DebugProcess(ctx context.Context, processName string, parameters map[string]interface{}) (map[string]interface{}, error) {
 	endpoint := fmt.Sprintf("/Processes('%s')/tm1.Debug?$expand=Breakpoints,Thread,CallStack($expand=Variables,Process($select=Name))",
-		url.PathEscape(processName))
+		escapeODataKey(processName))
 
 	payload := map[string]interface{}{}
 	if len(parameters) > 0 {
@@ -671,7 +671,7 @@ func (ps *ProcessService) DebugProcess(ctx context.Context, processName string,
 
 // DebugStepOver runs a single statement in the process (does not debug child processes)
 func (ps *ProcessService) DebugStepOver(ctx context.Context, debugID string) (map[string]interface{}, error) {
-	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/tm1.StepOver", url.PathEscape(debugID))
+	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/tm1.StepOver", escapeODataKey(debugID))
 
 	resp, err := ps.rest.Post(ctx, endpoint, nil)
 	if err != nil {
@@ -687,7 +687,7 @@ func (ps *ProcessService) DebugStepOver(ctx context.Context, debugID string) (ma
 
 // DebugStepIn runs a single statement and steps into child processes
 func (ps *ProcessService) DebugStepIn(ctx context.Context, debugID string) (map[string]interface{}, error) {
-	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/tm1.StepIn", url.PathEscape(debugID))
+	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/tm1.StepIn", escapeODataKey(debugID))
 
 	resp, err := ps.rest.Post(ctx, endpoint, nil)
 	if err != nil {
@@ -703,7 +703,7 @@ func (ps *ProcessService) DebugStepIn(ctx context.Context, debugID string) (map[
 
 // DebugStepOut resumes execution until current process finishes
 func (ps *ProcessService) DebugStepOut(ctx context.Context, debugID string) (map[string]interface{}, error) {
-	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/tm1.StepOut", url.PathEscape(debugID))
+	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/tm1.StepOut", escapeODataKey(debugID))
 
 	resp, err := ps.rest.Post(ctx, endpoint, nil)
 	if err != nil {
@@ -719,7 +719,7 @@ func (ps *ProcessService) DebugStepOut(ctx context.Context, debugID string) (map
 
 // DebugContinue resumes execution until next breakpoint
 func (ps *ProcessService) DebugContinue(ctx context.Context, debugID string) (map[string]interface{}, error) {
-	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/tm1.Continue", url.PathEscape(debugID))
+	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/tm1.Continue", escapeODataKey(debugID))
 
 	resp, err := ps.rest.Post(ctx, endpoint, nil)
 	if err != nil {
@@ -736,7 +736,7 @@ func (ps *ProcessService) DebugContinue(ctx context.Context, debugID string) (ma
 // getDebugContext is a helper to retrieve debug context
 func (ps *ProcessService) getDebugContext(ctx context.Context, debugID string) (map[string]interface{}, error) {
 	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')?$expand=Breakpoints,Thread,CallStack($expand=Variables,Process($select=Name))",
-		url.PathEscape(debugID))
+		escapeODataKey(debugID))
 
 	var result map[string]interface{}
 	err := ps.rest.JSON(ctx, "GET", endpoint, nil, &result)
@@ -749,7 +749,7 @@ func (ps *ProcessService) getDebugContext(ctx context.Context, debugID string) (
 
 // DebugGetBreakpoints retrieves all breakpoints for a debug session
 func (ps *ProcessService) DebugGetBreakpoints(ctx context.Context, debugID string) ([]*models.ProcessDebugBreakpoint, error) {
-	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/Breakpoints", url.PathEscape(debugID))
+	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/Breakpoints", escapeODataKey(debugID))
 
 	var response struct {
 		Value []*models.ProcessDebugBreakpoint `json:"value"`
@@ -770,7 +770,7 @@ func (ps *ProcessService) DebugAddBreakpoint(ctx context.Context, debugID string
 
 // DebugAddBreakpoints adds multiple breakpoints to a debug session
 func (ps *ProcessService) DebugAddBreakpoints(ctx context.Context, debugID string, breakpoints []*models.ProcessDebugBreakpoint) error {
-	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/Breakpoints", url.PathEscape(debugID))
+	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/Breakpoints", escapeODataKey(debugID))
 
 	bodyDicts := make([]map[string]interface{}, len(breakpoints))
 	for i, bp := range breakpoints {
@@ -794,7 +794,7 @@ func (ps *ProcessService) DebugAddBreakpoints(ctx context.Context, debugID strin
 // DebugRemoveBreakpoint removes a breakpoint from a debug session
 func (ps *ProcessService) DebugRemoveBreakpoint(ctx context.Context, debugID string, breakpointID int) error {
 	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/Breakpoints('%d')",
-		url.PathEscape(debugID), breakpointID)
+		escapeODataKey(debugID), breakpointID)
 
 	resp, err := ps.rest.Delete(ctx, endpoint)
 	if err != nil {
@@ -808,7 +808,7 @@ func (ps *ProcessService) DebugRemoveBreakpoint(ctx context.Context, debugID str
 // DebugUpdateBreakpoint updates a breakpoint in a debug session
 func (ps *ProcessService) DebugUpdateBreakpoint(ctx context.Context, debugID string, breakpoint *models.ProcessDebugBreakpoint) error {
 	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')/Breakpoints('%d')",
-		url.PathEscape(debugID), breakpoint.BreakpointID)
+		escapeODataKey(debugID), breakpoint.BreakpointID)
 
 	bodyJSON, err := json.Marshal(breakpoint)
 	if err != nil {
@@ -827,7 +827,7 @@ func (ps *ProcessService) DebugUpdateBreakpoint(ctx context.Context, debugID str
 // DebugGetVariableValues retrieves all variable values in a debug session
 func (ps *ProcessService) DebugGetVariableValues(ctx context.Context, debugID string) (map[string]interface{}, error) {
 	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')?$expand=CallStack($expand=Variables)",
-		url.PathEscape(debugID))
+		escapeODataKey(debugID))
 
 	var response map[string]interface{}
 	err := ps.rest.JSON(ctx, "GET", endpoint, nil, &response)
@@ -857,7 +857,7 @@ func (ps *ProcessService) DebugGetVariableValues(ctx context.Context, debugID st
 func (ps *ProcessService) DebugGetSingleVariableValue(ctx context.Context, debugID, variableName string) (interface{}, error) {
 	query := url.Values{}
 	query.Set("$expand", fmt.Sprintf("CallStack($expand=Variables($filter=tolower(Name) eq '%s';$select=Value))", strings.ToLower(variableName)))
-	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')?%s", url.PathEscape(debugID), EncodeODataQuery(query))
+	endpoint := fmt.Sprintf("/ProcessDebugContexts('%s')?%s", escapeODataKey(debugID), EncodeODataQuery(query))
 
 	var response map[string]interface{}
 	err := ps.rest.JSON(ctx, "GET", endpoint, nil, &response)
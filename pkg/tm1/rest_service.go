@@ -5,12 +5,17 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -36,6 +41,10 @@ type RestService struct {
 	client                      *http.Client
 	headers                     http.Header
 	auth                        AuthProvider
+	authMu                      sync.RWMutex
+	authOverridden              bool // auth was supplied via WithAuthProvider and is not rebuilt from Config
+	reauthMu                    sync.Mutex
+	sessionGeneration           atomic.Uint64 // incremented by every re-authentication
 	logger                      Logger
 	keepAlive                   bool
 	version                     string
@@ -44,8 +53,10 @@ type RestService struct {
 	reConnectOnSessionTimeout   bool
 	reConnectOnRemoteDisconnect bool
 	asyncRequestsMode           bool
+	retryPolicy                 *RetryPolicy
 	cancelAtTimeout             bool
 	timeout                     time.Duration
+	validateModels              bool
 }
 
 // NewRestService constructs a RestService using the provided configuration and options.
@@ -80,8 +91,10 @@ func NewRestService(cfg Config, opts ...RestOption) (*RestService, error) {
 		reConnectOnSessionTimeout:   cfg.ReConnectOnSessionTimeout,
 		reConnectOnRemoteDisconnect: cfg.ReConnectOnRemoteDisconnect,
 		asyncRequestsMode:           cfg.AsyncRequestsMode,
+		retryPolicy:                 cfg.RetryPolicy,
 		cancelAtTimeout:             cfg.CancelAtTimeout,
 		timeout:                     cfg.Timeout,
+		validateModels:              cfg.ValidateModels,
 	}
 
 	// Set default reconnection behavior if not specified
@@ -196,10 +209,16 @@ func (rs *RestService) setupAuthentication(cfg Config) error {
 
 	// Windows Integrated Authentication
 	if cfg.IntegratedLogin {
-		// Note: Windows integrated auth requires platform-specific implementation
-		// This is a placeholder - actual implementation would use SSPI on Windows
-		rs.authMode = AuthModeWIA
-		return fmt.Errorf("Windows Integrated Authentication not yet implemented in Go")
+		source, err := newKerberosTokenSource(cfg)
+		if err != nil {
+			return fmt.Errorf("integrated login: %w", err)
+		}
+		rs.auth = NegotiateAuth{
+			Source:  source,
+			Service: cfg.IntegratedLoginService,
+			Host:    cfg.IntegratedLoginHost,
+		}
+		return nil
 	}
 
 	// Basic authentication
@@ -226,6 +245,9 @@ func (rs *RestService) BaseURL() string {
 // Request executes an HTTP request against the TM1 REST API.
 // The caller is responsible for closing the returned response body.
 // If asyncRequestsMode is enabled, the request will use async mode automatically.
+// The request body is buffered so the request can be replayed once after re-authentication
+// when the session has timed out or the connection was dropped by the remote end, and
+// retried according to the configured RetryPolicy on transient errors.
 func (rs *RestService) Request(ctx context.Context, method, endpoint string, body io.Reader, opts ...RequestOption) (*http.Response, error) {
 	// If asyncRequestsMode is enabled, use async request handling
 	if rs.asyncRequestsMode {
@@ -233,26 +255,231 @@ func (rs *RestService) Request(ctx context.Context, method, endpoint string, bod
 		return resp, err
 	}
 
+	payload, err := readRequestBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := rs.retryPolicy
+	retry := policy.enabled() && policy.allowsMethod(method)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := rs.execute(ctx, method, endpoint, payload, opts...)
+		last := !retry || attempt >= policy.MaxAttempts
+
+		if err != nil {
+			if last || !policy.retryableError(err) {
+				return nil, err
+			}
+			delay := policy.backoff(attempt)
+			rs.logger.Printf("tm1go %s %s failed (attempt %d/%d), retrying in %v: %v", method, endpoint, attempt, policy.MaxAttempts, delay, err)
+			if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+				return nil, retryCancelled(sleepErr, err)
+			}
+			continue
+		}
+
+		if !last && policy.retryableStatus(resp.StatusCode) {
+			delay := policy.delayAfterResponse(attempt, resp)
+			rs.logger.Printf("tm1go %s %s returned status %d (attempt %d/%d), retrying in %v", method, endpoint, resp.StatusCode, attempt, policy.MaxAttempts, delay)
+			statusErr := newHTTPError(resp)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+				return nil, retryCancelled(sleepErr, statusErr)
+			}
+			continue
+		}
+
+		if httpErr := newHTTPError(resp); httpErr != nil {
+			resp.Body.Close()
+			return nil, httpErr
+		}
+
+		return resp, nil
+	}
+}
+
+// execute performs one logical request attempt, re-authenticating and replaying the
+// request once when the session has timed out or the remote end dropped the connection.
+// Non-success status codes are returned as responses so the caller can decide on retries.
+func (rs *RestService) execute(ctx context.Context, method, endpoint string, payload []byte, opts ...RequestOption) (*http.Response, error) {
+	generation := rs.sessionGeneration.Load()
+	resp, err := rs.send(ctx, method, endpoint, payload, opts...)
+	if err != nil {
+		if !rs.reConnectOnRemoteDisconnect || !isRemoteDisconnect(err) || ctx.Err() != nil {
+			return nil, fmt.Errorf("tm1 request failed: %w", err)
+		}
+		rs.logger.Printf("tm1go connection dropped by remote end, reconnecting: %v", err)
+		if reErr := rs.reauthenticate(generation); reErr != nil {
+			return nil, fmt.Errorf("tm1 request failed: %w (reconnect: %v)", err, reErr)
+		}
+		resp, err = rs.send(ctx, method, endpoint, payload, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("tm1 request failed: %w", err)
+		}
+	}
+
+	// A Negotiate challenge on a session request means the session has ended and a new
+	// one has to be negotiated
+	if resp.StatusCode == http.StatusUnauthorized && requestHadSession(resp.Request) && rs.sessionRenewable() &&
+		(rs.reConnectOnSessionTimeout || rs.negotiates() && negotiateChallenged(resp)) {
+		rs.logger.Printf("tm1go session timed out, re-authenticating")
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if err := rs.reauthenticate(generation); err != nil {
+			return nil, fmt.Errorf("re-authenticate: %w", err)
+		}
+		resp, err = rs.send(ctx, method, endpoint, payload, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("tm1 request failed: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// send builds and executes a single HTTP request from a buffered payload.
+func (rs *RestService) send(ctx context.Context, method, endpoint string, payload []byte, opts ...RequestOption) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
 	req, err := rs.buildRequest(ctx, method, endpoint, body, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	rs.logRequest(req, "")
+	suffix := ""
+	if req.Header.Get("Prefer") == "respond-async" {
+		suffix = " (async)"
+	}
+	rs.logRequest(req, suffix)
 
 	resp, err := rs.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("tm1 request failed: %w", err)
+		return nil, err
 	}
 
-	if httpErr := newHTTPError(resp); httpErr != nil {
-		resp.Body.Close()
-		return nil, httpErr
+	rs.authMu.RLock()
+	negotiate, ok := rs.auth.(NegotiateAuth)
+	rs.authMu.RUnlock()
+	if ok {
+		if err := negotiate.verifyResponse(resp); err != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			return nil, err
+		}
 	}
 
 	return resp, nil
 }
 
+// reauthenticate drops the current session cookies and re-runs the authentication
+// flow derived from Config, so the next request establishes a new TM1 session.
+// generation is the session generation the failed request was sent with; when another
+// request has re-authenticated since, the new session is kept and nothing is done.
+func (rs *RestService) reauthenticate(generation uint64) error {
+	rs.reauthMu.Lock()
+	defer rs.reauthMu.Unlock()
+	if rs.sessionGeneration.Load() != generation {
+		return nil
+	}
+	defer rs.sessionGeneration.Add(1)
+
+	if jar, ok := rs.client.Jar.(*sessionJar); ok {
+		if err := jar.Reset(); err != nil {
+			return fmt.Errorf("reset cookie jar: %w", err)
+		}
+	}
+
+	rs.authMu.Lock()
+	defer rs.authMu.Unlock()
+
+	// A custom auth provider is re-applied on every request as-is
+	if rs.authOverridden {
+		return nil
+	}
+
+	return rs.setupAuthentication(rs.kwargs)
+}
+
+// sessionRenewable reports whether a timed-out session can be replaced by logging in
+// again. A session passed in Config.SessionID cannot: re-authenticating would send the
+// same expired cookie.
+func (rs *RestService) sessionRenewable() bool {
+	rs.authMu.RLock()
+	defer rs.authMu.RUnlock()
+	return rs.authOverridden || rs.kwargs.SessionID == ""
+}
+
+// negotiates reports whether requests authenticate with Negotiate.
+func (rs *RestService) negotiates() bool {
+	rs.authMu.RLock()
+	defer rs.authMu.RUnlock()
+	_, ok := rs.auth.(NegotiateAuth)
+	return ok
+}
+
+// hasSession reports whether the cookie jar holds a TM1 session cookie for u.
+func (rs *RestService) hasSession(u *url.URL) bool {
+	if rs.client.Jar == nil {
+		return false
+	}
+	for _, cookie := range rs.client.Jar.Cookies(u) {
+		if (cookie.Name == "TM1SessionId" || cookie.Name == "paSession") && cookie.Value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// readRequestBody buffers a request body so it can be sent more than once.
+func readRequestBody(body io.Reader) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+	return payload, nil
+}
+
+// requestHadSession reports whether a TM1 session cookie was sent with the request.
+func requestHadSession(req *http.Request) bool {
+	if req == nil {
+		return false
+	}
+	for _, name := range []string{"TM1SessionId", "paSession"} {
+		if cookie, err := req.Cookie(name); err == nil && cookie.Value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isRemoteDisconnect reports whether err indicates the connection was closed by the server.
+func isRemoteDisconnect(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "read" {
+		return true
+	}
+	return strings.Contains(err.Error(), "server closed idle connection")
+}
+
 // JSON performs a request where the payload and response are JSON encoded.
 func (rs *RestService) JSON(ctx context.Context, method, endpoint string, payload any, dest any, opts ...RequestOption) error {
 	var body io.Reader
@@ -418,16 +645,15 @@ func (rs *RestService) RequestAsync(ctx context.Context, method, endpoint string
 	// Add async header
 	asyncOpts := append(opts, WithHeader("Prefer", "respond-async"))
 
-	req, err := rs.buildRequest(ctx, method, endpoint, body, asyncOpts...)
+	payload, err := readRequestBody(body)
 	if err != nil {
 		return nil, "", err
 	}
 
-	rs.logRequest(req, " (async)")
-
-	resp, err := rs.client.Do(req)
+	// Re-authenticate and replay like synchronous requests
+	resp, err := rs.execute(ctx, method, endpoint, payload, asyncOpts...)
 	if err != nil {
-		return nil, "", fmt.Errorf("tm1 async request failed: %w", err)
+		return nil, "", err
 	}
 
 	if httpErr := newHTTPError(resp); httpErr != nil {
@@ -856,8 +1082,17 @@ func (rs *RestService) buildRequest(ctx context.Context, method, endpoint string
 
 	req.Header = cloneHeader(rs.headers)
 
-	if rs.auth != nil {
-		if err := rs.auth.Apply(req); err != nil {
+	rs.authMu.RLock()
+	auth := rs.auth
+	rs.authMu.RUnlock()
+
+	// Negotiate tokens only establish a session; later requests are authenticated by it
+	if _, negotiate := auth.(NegotiateAuth); negotiate && rs.hasSession(req.URL) {
+		auth = nil
+	}
+
+	if auth != nil {
+		if err := auth.Apply(req); err != nil {
 			return nil, fmt.Errorf("apply auth: %w", err)
 		}
 	}
@@ -3,9 +3,12 @@ package tm1
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -341,6 +344,295 @@ func TestRestServiceHTTPMethods(t *testing.T) {
 	}
 }
 
+func TestRestServiceReauthenticatesOnSessionTimeout(t *testing.T) {
+	var calls, authCalls int
+	var replayedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if _, _, ok := r.BasicAuth(); ok {
+			authCalls++
+		}
+		cookie, err := r.Cookie("TM1SessionId")
+		switch {
+		case err != nil:
+			// New session
+			body, _ := io.ReadAll(r.Body)
+			replayedBody = string(body)
+			http.SetCookie(w, &http.Cookie{Name: "TM1SessionId", Value: fmt.Sprintf("session-%d", calls), Path: "/"})
+			w.WriteHeader(http.StatusOK)
+		case cookie.Value == "session-1":
+			// Session expired on the server
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false, User: "admin", Password: "apple"}
+	rs, err := NewRestService(cfg)
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	ctx := context.Background()
+	resp, err := rs.Get(ctx, "/Configuration/ProductVersion/$value")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	resp.Body.Close()
+	if rs.SessionID() != "session-1" {
+		t.Fatalf("SessionID() = %q, want session-1", rs.SessionID())
+	}
+
+	resp, err = rs.Post(ctx, "/Cubes('Sales')/tm1.Update", strings.NewReader(`{"Value":1}`))
+	if err != nil {
+		t.Fatalf("Post() after session timeout failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls != 3 || authCalls != 3 {
+		t.Fatalf("calls = %d, authCalls = %d, want 3/3", calls, authCalls)
+	}
+	if replayedBody != `{"Value":1}` {
+		t.Fatalf("replayed body = %q, want original payload", replayedBody)
+	}
+	if rs.SessionID() != "session-3" {
+		t.Fatalf("SessionID() = %q, want session-3", rs.SessionID())
+	}
+}
+
+func TestRestServiceAsyncReauthenticatesOnSessionTimeout(t *testing.T) {
+	var calls, asyncCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Prefer") == "respond-async" {
+			asyncCalls++
+		}
+		cookie, err := r.Cookie("TM1SessionId")
+		switch {
+		case err != nil:
+			http.SetCookie(w, &http.Cookie{Name: "TM1SessionId", Value: fmt.Sprintf("session-%d", calls), Path: "/"})
+			w.WriteHeader(http.StatusOK)
+		case cookie.Value == "session-1":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false, User: "admin", Password: "apple", AsyncRequestsMode: true}
+	rs, err := NewRestService(cfg)
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		resp, err := rs.Get(ctx, "/Configuration/ProductVersion/$value")
+		if err != nil {
+			t.Fatalf("Get() %d failed: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+
+	if calls != 3 || asyncCalls != 3 {
+		t.Fatalf("calls = %d, async calls = %d, want 3/3", calls, asyncCalls)
+	}
+	if rs.SessionID() != "session-3" {
+		t.Fatalf("SessionID() = %q, want session-3", rs.SessionID())
+	}
+}
+
+func TestRestServiceReauthenticatesOnce(t *testing.T) {
+	const requests = 5
+	var mu sync.Mutex
+	var expired sync.WaitGroup
+	expired.Add(requests)
+	sessions := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("TM1SessionId")
+		if err == nil && cookie.Value == "session-0" {
+			// Hold the 401s until every request has seen the expired session
+			expired.Done()
+			expired.Wait()
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			mu.Lock()
+			sessions++
+			http.SetCookie(w, &http.Cookie{Name: "TM1SessionId", Value: fmt.Sprintf("session-%d", sessions), Path: "/"})
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false, User: "admin", Password: "apple"}
+	rs, err := NewRestService(cfg)
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+	serverURL, _ := url.Parse(server.URL)
+	rs.client.Jar.SetCookies(serverURL, []*http.Cookie{{Name: "TM1SessionId", Value: "session-0", Path: "/"}})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := rs.Get(context.Background(), "/Cubes")
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Get() failed: %v", err)
+	}
+
+	if got := rs.sessionGeneration.Load(); got != 1 {
+		t.Errorf("re-authentications = %d, want 1", got)
+	}
+}
+
+func TestRestServiceNoReauthenticationWithSessionID(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false, SessionID: "expired"}
+	rs, err := NewRestService(cfg)
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	_, err = rs.Get(context.Background(), "/Cubes")
+	httpErr, ok := err.(*HTTPError)
+	if !ok || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Get() error = %v, want 401 HTTPError", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
+
+func TestRestServiceNoReauthenticationWithoutSession(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false, User: "admin", Password: "wrong"}
+	rs, err := NewRestService(cfg)
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	_, err = rs.Get(context.Background(), "/Cubes")
+	httpErr, ok := err.(*HTTPError)
+	if !ok || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Get() error = %v, want 401 HTTPError", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
+
+func TestRestServiceReconnectsOnRemoteDisconnect(t *testing.T) {
+	calls := 0
+	var replayedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// Drop the connection without responding
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack failed: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		replayedBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false, User: "admin", Password: "apple"}
+	rs, err := NewRestService(cfg)
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	resp, err := rs.Post(context.Background(), "/ExecuteMDX", strings.NewReader(`{"MDX":"SELECT"}`))
+	if err != nil {
+		t.Fatalf("Post() after remote disconnect failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+	if replayedBody != `{"MDX":"SELECT"}` {
+		t.Fatalf("replayed body = %q, want original payload", replayedBody)
+	}
+}
+
+func TestRestServiceWithReConnectDisabled(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if _, err := r.Cookie("TM1SessionId"); err != nil {
+			http.SetCookie(w, &http.Cookie{Name: "TM1SessionId", Value: "session", Path: "/"})
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false, User: "admin", Password: "apple"}
+	rs, err := NewRestService(cfg, WithReConnect(false, false))
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	ctx := context.Background()
+	resp, err := rs.Get(ctx, "/Cubes")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := rs.Get(ctx, "/Cubes"); err == nil {
+		t.Fatal("Get() expected 401 error with reconnect disabled")
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
 // Helper type for testing
 type testLogger struct {
 	messages []string
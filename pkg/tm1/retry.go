@@ -0,0 +1,161 @@
+package tm1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryInitialBackoff = 200 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMultiplier     = 2.0
+)
+
+// defaultRetryableStatusCodes lists the responses TM1 returns for transient conditions
+// such as a busy server (503) or an overloaded gateway.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how RestService retries requests that fail with transient errors.
+// A nil policy or MaxAttempts <= 1 disables retries.
+type RetryPolicy struct {
+	MaxAttempts          int           // Total number of attempts including the first one
+	InitialBackoff       time.Duration // Delay before the first retry (default: 200ms)
+	MaxBackoff           time.Duration // Upper bound for the delay between attempts (default: 5s)
+	Multiplier           float64       // Growth factor applied to the delay after each attempt (default: 2)
+	Jitter               float64       // Random spread applied to each delay as a fraction of it, between 0 and 1
+	RetryableStatusCodes []int         // Status codes that trigger a retry (default: 429, 500, 502, 503, 504)
+	RetryNonIdempotent   bool          // Also retry POST and PATCH requests; by default only GET, HEAD, OPTIONS, PUT and DELETE are retried
+}
+
+// DefaultRetryPolicy returns a policy with three attempts and exponential backoff
+// that only retries idempotent requests.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+		Multiplier:     defaultRetryMultiplier,
+		Jitter:         0.2,
+	}
+}
+
+// enabled reports whether the policy allows more than one attempt.
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+// allowsMethod reports whether requests with the given method may be retried.
+func (p *RetryPolicy) allowsMethod(method string) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryableStatus reports whether a response status code is considered transient.
+func (p *RetryPolicy) retryableStatus(statusCode int) bool {
+	codes := p.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = defaultRetryableStatusCodes
+	}
+	return SliceContains(codes, statusCode)
+}
+
+// retryableError reports whether a transport error is considered transient.
+func (p *RetryPolicy) retryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if isRemoteDisconnect(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	maxBackoff := p.maxBackoff()
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultRetryMultiplier
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(retry-1))
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+	if delay > float64(maxBackoff) {
+		delay = float64(maxBackoff)
+	}
+	return time.Duration(delay)
+}
+
+// delayAfterResponse returns the delay before the given retry, honouring a Retry-After
+// header sent with the response up to MaxBackoff.
+func (p *RetryPolicy) delayAfterResponse(retry int, resp *http.Response) time.Duration {
+	delay := p.backoff(retry)
+	if wait := retryAfter(resp); wait > delay {
+		delay = min(wait, p.maxBackoff())
+	}
+	return delay
+}
+
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff <= 0 {
+		return defaultRetryMaxBackoff
+	}
+	return p.MaxBackoff
+}
+
+// retryAfter parses a Retry-After header given in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	seconds, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After")))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// retryCancelled reports a retry whose backoff was cut short by the context, wrapping
+// both the context error and the failure of the last attempt.
+func retryCancelled(ctxErr, lastErr error) error {
+	return fmt.Errorf("%w: retry after %w", ctxErr, lastErr)
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
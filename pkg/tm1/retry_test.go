@@ -0,0 +1,188 @@
+package tm1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRestServiceRetriesTransientStatus(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"value":[]}`))
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		Address:     "localhost",
+		Port:        8882,
+		SSL:         false,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond},
+	}
+	rs, err := NewRestService(cfg)
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	var result struct {
+		Value []interface{} `json:"value"`
+	}
+	if err := rs.JSON(context.Background(), "GET", "/Cubes", nil, &result); err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("calls = %d, want 3", calls)
+	}
+}
+
+func TestRestServiceRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	policy := &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+	rs, err := NewRestService(cfg, WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	_, err = rs.Get(context.Background(), "/Cubes")
+	httpErr, ok := err.(*HTTPError)
+	if !ok || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("Get() error = %v, want 500 HTTPError", err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
+func TestRestServiceRetryNonIdempotent(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	rs, err := NewRestService(cfg, WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	if _, err := rs.Post(context.Background(), "/ExecuteMDX", strings.NewReader(`{}`)); err == nil {
+		t.Fatal("Post() expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("POST calls = %d, want 1", calls)
+	}
+
+	calls = 0
+	if _, err := rs.Delete(context.Background(), "/Cellsets('abc')"); err == nil {
+		t.Fatal("Delete() expected error")
+	}
+	if calls != 3 {
+		t.Fatalf("DELETE calls = %d, want 3", calls)
+	}
+
+	calls = 0
+	policy.RetryNonIdempotent = true
+	if _, err := rs.Post(context.Background(), "/ExecuteMDX", strings.NewReader(`{}`)); err == nil {
+		t.Fatal("Post() expected error")
+	}
+	if calls != 3 {
+		t.Fatalf("POST calls with RetryNonIdempotent = %d, want 3", calls)
+	}
+}
+
+func TestRestServiceRetryCancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rs, err := NewRestService(cfg, WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute}))
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	_, err = rs.Get(ctx, "/Cubes")
+	var httpErr *HTTPError
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Get() error = %v, want context.DeadlineExceeded wrapping the 503", err)
+	}
+}
+
+func TestRestServiceRetryStatusCodes(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryableStatusCodes: []int{http.StatusServiceUnavailable}}
+	rs, err := NewRestService(cfg, WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	rs.SetBaseURL(server.URL)
+
+	if _, err := rs.Get(context.Background(), "/Cubes"); err == nil {
+		t.Fatal("Get() expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1 for non-retryable status", calls)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3}
+
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{retry: 1, want: 100 * time.Millisecond},
+		{retry: 2, want: 300 * time.Millisecond},
+		{retry: 3, want: 900 * time.Millisecond},
+		{retry: 4, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.backoff(tt.retry); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.retry, got, tt.want)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		got := policy.backoff(1)
+		if got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("backoff(1) with jitter = %v, want within [50ms, 150ms]", got)
+		}
+	}
+}
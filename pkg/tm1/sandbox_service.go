@@ -1,5 +1,14 @@
 package tm1
 
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
 // SandboxService handles operations for TM1 sandboxes.
 type SandboxService struct {
 	rest *RestService
@@ -9,3 +18,145 @@ type SandboxService struct {
 func NewSandboxService(rest *RestService) *SandboxService {
 	return &SandboxService{rest: rest}
 }
+
+const sandboxSelect = "Name,IncludeInSandboxDimension,IsLoaded,IsActive,IsQueued"
+
+// Get retrieves a sandbox by name.
+func (ss *SandboxService) Get(ctx context.Context, sandboxName string) (*models.Sandbox, error) {
+	endpoint := fmt.Sprintf("/Sandboxes('%s')?$select=%s", escapeODataKey(sandboxName), sandboxSelect)
+
+	var sandbox models.Sandbox
+	if err := ss.rest.JSON(ctx, "GET", endpoint, nil, &sandbox); err != nil {
+		return nil, err
+	}
+
+	return &sandbox, nil
+}
+
+// GetAll retrieves all sandboxes visible to the current user.
+func (ss *SandboxService) GetAll(ctx context.Context) ([]*models.Sandbox, error) {
+	endpoint := "/Sandboxes?$select=" + sandboxSelect
+
+	var response struct {
+		Value []*models.Sandbox `json:"value"`
+	}
+	if err := ss.rest.JSON(ctx, "GET", endpoint, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Value, nil
+}
+
+// GetAllNames retrieves the names of all sandboxes visible to the current user.
+func (ss *SandboxService) GetAllNames(ctx context.Context) ([]string, error) {
+	var response struct {
+		Value []struct {
+			Name string `json:"Name"`
+		} `json:"value"`
+	}
+	if err := ss.rest.JSON(ctx, "GET", "/Sandboxes?$select=Name", nil, &response); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(response.Value))
+	for i, sandbox := range response.Value {
+		names[i] = sandbox.Name
+	}
+
+	return names, nil
+}
+
+// Create creates a new sandbox.
+func (ss *SandboxService) Create(ctx context.Context, sandbox *models.Sandbox) error {
+	body, err := sandbox.Body()
+	if err != nil {
+		return fmt.Errorf("failed to build sandbox body: %w", err)
+	}
+
+	resp, err := ss.rest.Post(ctx, "/Sandboxes", strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// Update updates an existing sandbox.
+func (ss *SandboxService) Update(ctx context.Context, sandbox *models.Sandbox) error {
+	body, err := sandbox.Body()
+	if err != nil {
+		return fmt.Errorf("failed to build sandbox body: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/Sandboxes('%s')", escapeODataKey(sandbox.Name))
+	resp, err := ss.rest.Patch(ctx, endpoint, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// Delete deletes a sandbox.
+func (ss *SandboxService) Delete(ctx context.Context, sandboxName string) error {
+	endpoint := fmt.Sprintf("/Sandboxes('%s')", escapeODataKey(sandboxName))
+	resp, err := ss.rest.Delete(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// Exists checks if a sandbox exists.
+func (ss *SandboxService) Exists(ctx context.Context, sandboxName string) (bool, error) {
+	endpoint := fmt.Sprintf("/Sandboxes('%s')?$select=Name", escapeODataKey(sandboxName))
+	resp, err := ss.rest.Get(ctx, endpoint)
+	if err != nil {
+		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == 200, nil
+}
+
+// Publish commits the changes of a sandbox to base data.
+func (ss *SandboxService) Publish(ctx context.Context, sandboxName string) error {
+	return ss.postAction(ctx, sandboxName, "tm1.Publish", nil)
+}
+
+// Reset discards all changes made in a sandbox.
+func (ss *SandboxService) Reset(ctx context.Context, sandboxName string) error {
+	return ss.postAction(ctx, sandboxName, "tm1.DiscardChanges", nil)
+}
+
+// Merge merges the changes of the source sandbox into the target sandbox.
+// If cleanAfter is true, the source sandbox is reset after a successful merge.
+func (ss *SandboxService) Merge(ctx context.Context, sourceSandboxName, targetSandboxName string, cleanAfter bool) error {
+	payload := map[string]interface{}{
+		"Target@odata.bind": fmt.Sprintf("Sandboxes('%s')", escapeODataKey(targetSandboxName)),
+		"CleanAfter":        cleanAfter,
+	}
+	return ss.postAction(ctx, sourceSandboxName, "tm1.Merge", payload)
+}
+
+// Load loads a sandbox into memory.
+func (ss *SandboxService) Load(ctx context.Context, sandboxName string) error {
+	return ss.postAction(ctx, sandboxName, "tm1.Load", nil)
+}
+
+// Unload unloads a sandbox from memory.
+func (ss *SandboxService) Unload(ctx context.Context, sandboxName string) error {
+	return ss.postAction(ctx, sandboxName, "tm1.Unload", nil)
+}
+
+func (ss *SandboxService) postAction(ctx context.Context, sandboxName, action string, payload interface{}) error {
+	endpoint := fmt.Sprintf("/Sandboxes('%s')/%s", escapeODataKey(sandboxName), action)
+	return ss.rest.JSON(ctx, "POST", endpoint, payload, nil)
+}
@@ -0,0 +1,163 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+func TestSandboxServiceGetAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/Sandboxes" && strings.Contains(r.URL.RawQuery, "IsQueued") {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"value":[{"Name":"Plan A","IncludeInSandboxDimension":true,"IsLoaded":true,"IsActive":false,"IsQueued":false},{"Name":"Hidden","IncludeInSandboxDimension":false,"IsLoaded":false,"IsActive":false,"IsQueued":true}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rest, _ := NewRestService(cfg)
+	mockBaseURL, _ := url.Parse(server.URL)
+	rest.SetBaseURL(mockBaseURL.String())
+
+	service := NewSandboxService(rest)
+	sandboxes, err := service.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(sandboxes) != 2 {
+		t.Fatalf("GetAll() len = %d, want 2", len(sandboxes))
+	}
+	if sandboxes[0].Name != "Plan A" || !sandboxes[0].IncludeInSandboxDimension || !sandboxes[0].IsLoaded {
+		t.Fatalf("GetAll() unexpected first sandbox: %#v", sandboxes[0])
+	}
+	if sandboxes[1].IncludeInSandboxDimension || !sandboxes[1].IsQueued {
+		t.Fatalf("GetAll() unexpected second sandbox: %#v", sandboxes[1])
+	}
+}
+
+func TestSandboxServiceExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Sandboxes('Plan A')":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"Name":"Plan A"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rest, _ := NewRestService(cfg)
+	mockBaseURL, _ := url.Parse(server.URL)
+	rest.SetBaseURL(mockBaseURL.String())
+
+	service := NewSandboxService(rest)
+	ctx := context.Background()
+
+	exists, err := service.Exists(ctx, "Plan A")
+	if err != nil {
+		t.Fatalf("Exists() error = %v", err)
+	}
+	if !exists {
+		t.Fatal("Exists() = false, want true")
+	}
+
+	exists, err = service.Exists(ctx, "Missing")
+	if err != nil {
+		t.Fatalf("Exists() missing error = %v", err)
+	}
+	if exists {
+		t.Fatal("Exists() = true, want false")
+	}
+}
+
+func TestSandboxServiceLifecycle(t *testing.T) {
+	calls := make([]string, 0, 5)
+	var createBody, mergeBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/Sandboxes":
+			_ = json.NewDecoder(r.Body).Decode(&createBody)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "POST" && r.URL.Path == "/Sandboxes('Plan A')/tm1.Merge":
+			_ = json.NewDecoder(r.Body).Decode(&mergeBody)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "POST" && strings.HasPrefix(r.URL.Path, "/Sandboxes('Plan A')/tm1."):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE" && r.URL.Path == "/Sandboxes('Plan A')":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{Address: "localhost", Port: 8882, SSL: false}
+	rest, _ := NewRestService(cfg)
+	mockBaseURL, _ := url.Parse(server.URL)
+	rest.SetBaseURL(mockBaseURL.String())
+
+	service := NewSandboxService(rest)
+	ctx := context.Background()
+
+	if err := service.Create(ctx, models.NewSandbox("Plan A")); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if createBody["Name"] != "Plan A" || createBody["IncludeInSandboxDimension"] != true {
+		t.Fatalf("Create() unexpected body: %#v", createBody)
+	}
+	if _, ok := createBody["IsLoaded"]; ok {
+		t.Fatalf("Create() body should not include read-only properties: %#v", createBody)
+	}
+
+	if err := service.Merge(ctx, "Plan A", "Plan B", true); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if mergeBody["Target@odata.bind"] != "Sandboxes('Plan%20B')" || mergeBody["CleanAfter"] != true {
+		t.Fatalf("Merge() unexpected body: %#v", mergeBody)
+	}
+	if err := service.Merge(ctx, "Plan A", "Bob's Plan", false); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if mergeBody["Target@odata.bind"] != "Sandboxes('Bob%27%27s%20Plan')" {
+		t.Fatalf("Merge() unexpected binding: %#v", mergeBody)
+	}
+
+	if err := service.Publish(ctx, "Plan A"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := service.Reset(ctx, "Plan A"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if err := service.Delete(ctx, "Plan A"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	want := []string{
+		"POST /Sandboxes",
+		"POST /Sandboxes('Plan A')/tm1.Merge",
+		"POST /Sandboxes('Plan A')/tm1.Merge",
+		"POST /Sandboxes('Plan A')/tm1.Publish",
+		"POST /Sandboxes('Plan A')/tm1.DiscardChanges",
+		"DELETE /Sandboxes('Plan A')",
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("call %d = %q, want %q", i, calls[i], want[i])
+		}
+	}
+}
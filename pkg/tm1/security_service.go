@@ -76,7 +76,7 @@ func (ss *SecurityService) GetUser(ctx context.Context, userName string) (*model
 	query := url.Values{}
 	query.Set("$select", "Name,FriendlyName,Password,Type,Enabled")
 	query.Set("$expand", "Groups")
-	endpoint := fmt.Sprintf("/Users('%s')?%s", url.PathEscape(actual), EncodeODataQuery(query))
+	endpoint := fmt.Sprintf("/Users('%s')?%s", escapeODataKey(actual), EncodeODataQuery(query))
 
 	var user models.User
 	if err := ss.rest.JSON(ctx, "GET", endpoint, nil, &user); err != nil {
@@ -127,14 +127,14 @@ func (ss *SecurityService) UpdateUser(ctx context.Context, user *models.User) er
 		}
 	}
 
-	endpoint := fmt.Sprintf("/Users('%s')", url.PathEscape(user.Name))
+	endpoint := fmt.Sprintf("/Users('%s')", escapeODataKey(user.Name))
 	body := ss.buildUserPayload(user)
 	return ss.rest.JSON(ctx, "PATCH", endpoint, body, nil)
 }
 
 // UpdateUserPassword updates only the user password.
 func (ss *SecurityService) UpdateUserPassword(ctx context.Context, userName, password string) error {
-	endpoint := fmt.Sprintf("/Users('%s')", url.PathEscape(userName))
+	endpoint := fmt.Sprintf("/Users('%s')", escapeODataKey(userName))
 	return ss.rest.JSON(ctx, "PATCH", endpoint, map[string]string{"Password": password}, nil)
 }
 
@@ -153,7 +153,7 @@ func (ss *SecurityService) DeleteUser(ctx context.Context, userName string) erro
 		return err
 	}
 
-	resp, err := ss.rest.Delete(ctx, fmt.Sprintf("/Users('%s')", url.PathEscape(actualName)))
+	resp, err := ss.rest.Delete(ctx, fmt.Sprintf("/Users('%s')", escapeODataKey(actualName)))
 	if err != nil {
 		return err
 	}
@@ -177,7 +177,7 @@ func (ss *SecurityService) DeleteGroup(ctx context.Context, groupName string) er
 		return err
 	}
 
-	resp, err := ss.rest.Delete(ctx, fmt.Sprintf("/Groups('%s')", url.PathEscape(actualName)))
+	resp, err := ss.rest.Delete(ctx, fmt.Sprintf("/Groups('%s')", escapeODataKey(actualName)))
 	if err != nil {
 		return err
 	}
@@ -225,7 +225,7 @@ func (ss *SecurityService) GetUsersFromGroup(ctx context.Context, groupName stri
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/Groups('%s')?$expand=Users($select=Name,FriendlyName,Password,Type,Enabled;$expand=Groups)", url.PathEscape(actualGroup))
+	endpoint := fmt.Sprintf("/Groups('%s')?$expand=Users($select=Name,FriendlyName,Password,Type,Enabled;$expand=Groups)", escapeODataKey(actualGroup))
 	var response struct {
 		Users []*models.User `json:"Users"`
 	}
@@ -242,7 +242,7 @@ func (ss *SecurityService) GetUserNamesFromGroup(ctx context.Context, groupName
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/Groups('%s')?$expand=Users($expand=Groups)", url.PathEscape(actualGroup))
+	endpoint := fmt.Sprintf("/Groups('%s')?$expand=Users($expand=Groups)", escapeODataKey(actualGroup))
 	var response struct {
 		Users []struct {
 			Name string `json:"Name"`
@@ -264,7 +264,7 @@ func (ss *SecurityService) GetGroups(ctx context.Context, userName string) ([]st
 	if err != nil {
 		return nil, err
 	}
-	endpoint := fmt.Sprintf("/Users('%s')/Groups", url.PathEscape(actualName))
+	endpoint := fmt.Sprintf("/Users('%s')/Groups", escapeODataKey(actualName))
 
 	var response struct {
 		Value []struct {
@@ -310,7 +310,7 @@ func (ss *SecurityService) AddUserToGroups(ctx context.Context, userName string,
 		"Groups@odata.bind": bind,
 	}
 
-	endpoint := fmt.Sprintf("/Users('%s')", url.PathEscape(actualUser))
+	endpoint := fmt.Sprintf("/Users('%s')", escapeODataKey(actualUser))
 	return ss.rest.JSON(ctx, "PATCH", endpoint, payload, nil)
 }
 
@@ -333,7 +333,7 @@ func (ss *SecurityService) RemoveUserFromGroup(ctx context.Context, groupName, u
 		return err
 	}
 
-	endpoint := fmt.Sprintf("/Users('%s')/Groups?$id=Groups('%s')", url.PathEscape(actualUser), url.PathEscape(actualGroup))
+	endpoint := fmt.Sprintf("/Users('%s')/Groups?$id=Groups('%s')", escapeODataKey(actualUser), escapeODataKey(actualGroup))
 	resp, err := ss.rest.Delete(ctx, endpoint)
 	if err != nil {
 		return err
@@ -385,7 +385,7 @@ func (ss *SecurityService) SecurityRefresh(ctx context.Context) error {
 
 // UserExists checks if a user exists.
 func (ss *SecurityService) UserExists(ctx context.Context, userName string) (bool, error) {
-	endpoint := fmt.Sprintf("/Users('%s')", url.PathEscape(userName))
+	endpoint := fmt.Sprintf("/Users('%s')", escapeODataKey(userName))
 	resp, err := ss.rest.Get(ctx, endpoint)
 	if err != nil {
 		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
@@ -400,7 +400,7 @@ func (ss *SecurityService) UserExists(ctx context.Context, userName string) (boo
 
 // GroupExists checks if a group exists.
 func (ss *SecurityService) GroupExists(ctx context.Context, groupName string) (bool, error) {
-	endpoint := fmt.Sprintf("/Groups('%s')", url.PathEscape(groupName))
+	endpoint := fmt.Sprintf("/Groups('%s')", escapeODataKey(groupName))
 	resp, err := ss.rest.Get(ctx, endpoint)
 	if err != nil {
 		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
@@ -556,9 +556,7 @@ func containsInsensitive(items []string, candidate string) bool {
 }
 
 func normalizeCaseSpace(value string) string {
-	value = strings.TrimSpace(value)
-	value = strings.ReplaceAll(value, " ", "")
-	return strings.ToLower(value)
+	return models.NormalizeName(value)
 }
 
 func isTruthy(value interface{}) bool {
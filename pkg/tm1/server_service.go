@@ -490,7 +490,7 @@ func (ss *ServerService) UpdateMessageLoggerLevel(ctx context.Context, logger st
 		return fmt.Errorf("%s is not a valid logger level", level)
 	}
 
-	endpoint := fmt.Sprintf("/Loggers('%s')", url.PathEscape(logger))
+	endpoint := fmt.Sprintf("/Loggers('%s')", escapeODataKey(logger))
 	return ss.rest.JSON(ctx, "PATCH", endpoint, map[string]int{"Level": idx}, nil)
 }
 
@@ -78,7 +78,7 @@ func (ss *SessionService) GetThreadsForCurrent(ctx context.Context, excludeIdle
 
 // Close closes a session by ID.
 func (ss *SessionService) Close(ctx context.Context, sessionID interface{}) error {
-	endpoint := fmt.Sprintf("/Sessions('%s')/tm1.Close", url.PathEscape(fmt.Sprintf("%v", sessionID)))
+	endpoint := fmt.Sprintf("/Sessions('%s')/tm1.Close", escapeODataKey(fmt.Sprintf("%v", sessionID)))
 	resp, err := ss.rest.Post(ctx, endpoint, strings.NewReader(""))
 	if err != nil {
 		return err
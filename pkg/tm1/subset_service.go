@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
 	"strings"
 
 	"github.com/andreyea/tm1go/pkg/models"
@@ -30,8 +29,8 @@ func (ss *SubsetService) Create(ctx context.Context, subset *models.Subset, priv
 	}
 
 	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies('%s')/%s",
-		url.PathEscape(subset.DimensionName),
-		url.PathEscape(subset.HierarchyName),
+		escapeODataKey(subset.DimensionName),
+		escapeODataKey(subset.HierarchyName),
 		subsetsType)
 
 	body, err := subset.Body()
@@ -59,10 +58,10 @@ func (ss *SubsetService) Get(ctx context.Context, subsetName, dimensionName, hie
 	}
 
 	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies('%s')/%s('%s')?$expand=Hierarchy($select=Dimension,Name),Elements($select=Name)&$select=*,Alias",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 		subsetsType,
-		url.PathEscape(subsetName))
+		escapeODataKey(subsetName))
 
 	resp, err := ss.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -95,8 +94,8 @@ func (ss *SubsetService) GetAllNames(ctx context.Context, dimensionName, hierarc
 	}
 
 	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies('%s')/%s?$select=Name",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 		subsetsType)
 
 	resp, err := ss.rest.Get(ctx, endpoint)
@@ -139,10 +138,10 @@ func (ss *SubsetService) Update(ctx context.Context, subset *models.Subset, priv
 	}
 
 	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies('%s')/%s('%s')",
-		url.PathEscape(subset.DimensionName),
-		url.PathEscape(subset.HierarchyName),
+		escapeODataKey(subset.DimensionName),
+		escapeODataKey(subset.HierarchyName),
 		subsetsType,
-		url.PathEscape(subset.Name))
+		escapeODataKey(subset.Name))
 
 	body, err := subset.Body()
 	if err != nil {
@@ -180,10 +179,10 @@ func (ss *SubsetService) MakeStatic(ctx context.Context, subsetName, dimensionNa
 	}
 
 	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies('%s')/%s('%s')/tm1.SaveAs",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 		subsetsType,
-		url.PathEscape(subsetName))
+		escapeODataKey(subsetName))
 
 	_, err = ss.rest.Post(ctx, endpoint, strings.NewReader(string(body)))
 	if err != nil {
@@ -219,10 +218,10 @@ func (ss *SubsetService) Delete(ctx context.Context, subsetName, dimensionName,
 	}
 
 	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies('%s')/%s('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 		subsetsType,
-		url.PathEscape(subsetName))
+		escapeODataKey(subsetName))
 
 	_, err := ss.rest.Delete(ctx, endpoint)
 	if err != nil {
@@ -244,10 +243,10 @@ func (ss *SubsetService) Exists(ctx context.Context, subsetName, dimensionName,
 	}
 
 	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies('%s')/%s('%s')",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 		subsetsType,
-		url.PathEscape(subsetName))
+		escapeODataKey(subsetName))
 
 	resp, err := ss.rest.Get(ctx, endpoint)
 	if err != nil {
@@ -269,10 +268,10 @@ func (ss *SubsetService) DeleteElementsFromStaticSubset(ctx context.Context, dim
 	}
 
 	endpoint := fmt.Sprintf("/Dimensions('%s')/Hierarchies('%s')/%s('%s')/Elements/$ref",
-		url.PathEscape(dimensionName),
-		url.PathEscape(hierarchyName),
+		escapeODataKey(dimensionName),
+		escapeODataKey(hierarchyName),
 		subsetsType,
-		url.PathEscape(subsetName))
+		escapeODataKey(subsetName))
 
 	_, err := ss.rest.Delete(ctx, endpoint)
 	if err != nil {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net/url"
 	"strings"
 )
 
@@ -55,7 +54,7 @@ func (ts *ThreadService) Cancel(ctx context.Context, threadID int) error {
 		return err
 	}
 
-	endpoint := fmt.Sprintf("/Threads('%s')/tm1.CancelOperation", url.PathEscape(fmt.Sprintf("%d", threadID)))
+	endpoint := fmt.Sprintf("/Threads('%s')/tm1.CancelOperation", escapeODataKey(fmt.Sprintf("%d", threadID)))
 	resp, err := ts.rest.Post(ctx, endpoint, strings.NewReader(""))
 	if err != nil {
 		return err
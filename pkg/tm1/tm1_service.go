@@ -30,6 +30,7 @@ type TM1Service struct {
 	Monitoring    *MonitoringService
 	Server        *ServerService
 	Configuration *ConfigurationService
+	Annotations   *AnnotationService
 }
 
 // NewTM1Service constructs a TM1Service with the supplied configuration.
@@ -69,6 +70,7 @@ func NewTM1Service(cfg Config, opts ...RestOption) (*TM1Service, error) {
 		Monitoring:    NewMonitoringService(rest),
 		Server:        NewServerService(rest),
 		Configuration: NewConfigurationService(rest),
+		Annotations:   NewAnnotationService(rest),
 	}, nil
 }
 
@@ -50,7 +50,7 @@ func (us *UserService) GetActive(ctx context.Context) ([]*models.User, error) {
 
 // IsActive checks whether a user is currently active.
 func (us *UserService) IsActive(ctx context.Context, userName string) (bool, error) {
-	endpoint := fmt.Sprintf("/Users('%s')/IsActive", url.PathEscape(userName))
+	endpoint := fmt.Sprintf("/Users('%s')/IsActive", escapeODataKey(userName))
 	var response struct {
 		Value bool `json:"value"`
 	}
@@ -62,7 +62,7 @@ func (us *UserService) IsActive(ctx context.Context, userName string) (bool, err
 
 // Disconnect disconnects a user session.
 func (us *UserService) Disconnect(ctx context.Context, userName string) error {
-	endpoint := fmt.Sprintf("/Users('%s')/tm1.Disconnect", url.PathEscape(userName))
+	endpoint := fmt.Sprintf("/Users('%s')/tm1.Disconnect", escapeODataKey(userName))
 	resp, err := us.rest.Post(ctx, endpoint, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,257 @@
+package tm1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+// NativeViewBuilder assembles a native view axis by axis.
+//
+//	view, err := NewNativeViewBuilder("Sales").
+//		Name("Revenue by Region").
+//		Columns("Period", models.NewDynamicSubset("Period", "", "", "{[Period].[Period].Members}")).
+//		RowElements("Region", "North", "South").
+//		Title("Version", nil, "Actual").
+//		SuppressEmpty().
+//		Create(ctx, client.Rest(), false)
+//
+// Subset definitions are stored with the view unless Subset.Bind refers the view to a
+// subset stored on the server. CreateSubsets creates or updates the named subsets on
+// the server along with the view and binds the view to them.
+type NativeViewBuilder struct {
+	cubeName             string
+	name                 string
+	columns              []*models.Subset
+	rows                 []*models.Subset
+	titles               []models.ViewTitleAxis
+	suppressEmptyColumns bool
+	suppressEmptyRows    bool
+	formatString         string
+	createSubsets        bool
+	privateSubsets       bool
+	err                  error
+}
+
+// NewNativeViewBuilder creates a builder for a native view on the cube.
+func NewNativeViewBuilder(cubeName string) *NativeViewBuilder {
+	return &NativeViewBuilder{cubeName: cubeName}
+}
+
+// Name sets the name of the view.
+func (b *NativeViewBuilder) Name(viewName string) *NativeViewBuilder {
+	b.name = viewName
+	return b
+}
+
+// Columns adds a dimension to the columns axis. A nil subset selects all members.
+func (b *NativeViewBuilder) Columns(dimensionName string, subset *models.Subset) *NativeViewBuilder {
+	b.columns = append(b.columns, b.axisSubset(dimensionName, subset))
+	return b
+}
+
+// ColumnElements adds a dimension to the columns axis with a static list of elements.
+func (b *NativeViewBuilder) ColumnElements(dimensionName string, elements ...string) *NativeViewBuilder {
+	return b.Columns(dimensionName, models.NewStaticSubset(dimensionName, "", "", elements))
+}
+
+// Rows adds a dimension to the rows axis. A nil subset selects all members.
+func (b *NativeViewBuilder) Rows(dimensionName string, subset *models.Subset) *NativeViewBuilder {
+	b.rows = append(b.rows, b.axisSubset(dimensionName, subset))
+	return b
+}
+
+// RowElements adds a dimension to the rows axis with a static list of elements.
+func (b *NativeViewBuilder) RowElements(dimensionName string, elements ...string) *NativeViewBuilder {
+	return b.Rows(dimensionName, models.NewStaticSubset(dimensionName, "", "", elements))
+}
+
+// Title adds a dimension to the titles with the selected element. A nil subset
+// contains only the selected element.
+func (b *NativeViewBuilder) Title(dimensionName string, subset *models.Subset, selected string) *NativeViewBuilder {
+	if subset == nil && selected != "" {
+		subset = models.NewStaticSubset(dimensionName, "", "", []string{selected})
+	}
+	subset = b.axisSubset(dimensionName, subset)
+
+	title := models.ViewTitleAxis{Subset: subset}
+	if selected != "" {
+		title.Selected = &models.ViewSelectedElement{
+			Name:          selected,
+			DimensionName: subset.DimensionName,
+			HierarchyName: subset.HierarchyName,
+		}
+	}
+	b.titles = append(b.titles, title)
+	return b
+}
+
+// SuppressEmpty suppresses empty rows and columns.
+func (b *NativeViewBuilder) SuppressEmpty() *NativeViewBuilder {
+	b.suppressEmptyColumns = true
+	b.suppressEmptyRows = true
+	return b
+}
+
+// SuppressEmptyColumns suppresses empty columns.
+func (b *NativeViewBuilder) SuppressEmptyColumns() *NativeViewBuilder {
+	b.suppressEmptyColumns = true
+	return b
+}
+
+// SuppressEmptyRows suppresses empty rows.
+func (b *NativeViewBuilder) SuppressEmptyRows() *NativeViewBuilder {
+	b.suppressEmptyRows = true
+	return b
+}
+
+// FormatString sets the display format of the view.
+func (b *NativeViewBuilder) FormatString(format string) *NativeViewBuilder {
+	b.formatString = format
+	return b
+}
+
+// CreateSubsets makes Create store the named subsets of the view on the server,
+// as private or public subsets, before the view is created, and bind the view to them.
+func (b *NativeViewBuilder) CreateSubsets(private bool) *NativeViewBuilder {
+	b.createSubsets = true
+	b.privateSubsets = private
+	return b
+}
+
+// axisSubset completes the dimension and hierarchy of a subset for the dimension.
+func (b *NativeViewBuilder) axisSubset(dimensionName string, subset *models.Subset) *models.Subset {
+	if dimensionName == "" && b.err == nil {
+		b.err = fmt.Errorf("view axis requires a dimension name")
+	}
+
+	if subset == nil {
+		subset = models.NewDynamicSubset(dimensionName, "", "", "")
+	} else {
+		copied := *subset
+		subset = &copied
+	}
+
+	if subset.DimensionName == "" {
+		subset.DimensionName = dimensionName
+	} else if !strings.EqualFold(subset.DimensionName, dimensionName) && b.err == nil {
+		b.err = fmt.Errorf("subset '%s' belongs to dimension '%s', not '%s'", subset.Name, subset.DimensionName, dimensionName)
+	}
+	if subset.HierarchyName == "" {
+		subset.HierarchyName = dimensionName
+	}
+	if subset.Name == "" && subset.Expression == "" && len(subset.Elements) == 0 {
+		subset.Expression = fmt.Sprintf("{TM1SUBSETALL(%s)}", MDXHierarchy(subset.DimensionName, subset.HierarchyName))
+	}
+	return subset
+}
+
+// Build validates the layout and returns the view definition.
+func (b *NativeViewBuilder) Build() (*models.NativeView, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.cubeName == "" {
+		return nil, fmt.Errorf("view requires a cube name")
+	}
+	if b.name == "" {
+		return nil, fmt.Errorf("view requires a name")
+	}
+	if len(b.columns) == 0 {
+		return nil, fmt.Errorf("view '%s' requires at least one column dimension", b.name)
+	}
+
+	seen := make(map[string]bool)
+	for _, subset := range b.subsets() {
+		key := strings.ToLower(strings.ReplaceAll(subset.DimensionName, " ", ""))
+		if seen[key] {
+			return nil, fmt.Errorf("dimension '%s' is used more than once in view '%s'", subset.DimensionName, b.name)
+		}
+		seen[key] = true
+	}
+
+	view := &models.NativeView{
+		Type:                 "#ibm.tm1.api.v1.NativeView",
+		Cube:                 &models.Cube{Name: b.cubeName},
+		Name:                 b.name,
+		SuppressEmptyColumns: b.suppressEmptyColumns,
+		SuppressEmptyRows:    b.suppressEmptyRows,
+		FormatString:         b.formatString,
+	}
+	for _, subset := range b.columns {
+		view.Columns = append(view.Columns, models.ViewAxis{Subset: b.viewSubset(subset)})
+	}
+	for _, subset := range b.rows {
+		view.Rows = append(view.Rows, models.ViewAxis{Subset: b.viewSubset(subset)})
+	}
+	for _, title := range b.titles {
+		title.Subset = b.viewSubset(title.Subset)
+		view.Titles = append(view.Titles, title)
+	}
+
+	return view, nil
+}
+
+// viewSubset returns the subset as referenced by the view.
+func (b *NativeViewBuilder) viewSubset(subset *models.Subset) *models.Subset {
+	copied := *subset
+	if b.createSubsets && copied.Name != "" {
+		copied.Bind = true
+		copied.Private = b.privateSubsets
+	}
+	return &copied
+}
+
+// subsets returns the subsets of all axes in column, row, title order.
+func (b *NativeViewBuilder) subsets() []*models.Subset {
+	subsets := make([]*models.Subset, 0, len(b.columns)+len(b.rows)+len(b.titles))
+	subsets = append(subsets, b.columns...)
+	subsets = append(subsets, b.rows...)
+	for _, title := range b.titles {
+		subsets = append(subsets, title.Subset)
+	}
+	return subsets
+}
+
+// Create validates the view against the dimensions of the cube, stores its named
+// subsets when CreateSubsets was requested, and creates the view.
+func (b *NativeViewBuilder) Create(ctx context.Context, rest *RestService, private bool) (*models.NativeView, error) {
+	view, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	if b.createSubsets && b.privateSubsets && !private {
+		return nil, fmt.Errorf("public view '%s' cannot refer to private subsets", b.name)
+	}
+
+	dimensionNames, err := NewCubeService(rest).GetDimensionNames(ctx, b.cubeName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkViewDimensions(view, b.cubeName, dimensionNames); err != nil {
+		return nil, err
+	}
+
+	if b.createSubsets {
+		subsetService := NewSubsetService(rest)
+		for _, subset := range b.subsets() {
+			if subset.Name == "" {
+				continue
+			}
+			if subset.Expression == "" && len(subset.Elements) == 0 {
+				return nil, fmt.Errorf("subset '%s' has neither elements nor an expression", subset.Name)
+			}
+			if err := subsetService.UpdateOrCreate(ctx, subset, b.privateSubsets); err != nil {
+				return nil, fmt.Errorf("subset '%s': %w", subset.Name, err)
+			}
+		}
+	}
+
+	if err := NewViewService(rest).Create(ctx, b.cubeName, view, private); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
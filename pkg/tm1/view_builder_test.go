@@ -0,0 +1,154 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+func TestNativeViewBuilderBuild(t *testing.T) {
+	view, err := NewNativeViewBuilder("Sales").
+		Name("Revenue").
+		Columns("Period", nil).
+		RowElements("Region", "North", "South").
+		Title("Version", nil, "Actual").
+		SuppressEmpty().
+		Build()
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	if view.Cube.Name != "Sales" || view.Name != "Revenue" || !view.SuppressEmptyColumns || !view.SuppressEmptyRows {
+		t.Errorf("view = %+v", view)
+	}
+	if got := view.Columns[0].Subset.Expression; got != "{TM1SUBSETALL([Period].[Period])}" {
+		t.Errorf("column expression = %q", got)
+	}
+	if got := view.Rows[0].Subset; got.DimensionName != "Region" || got.HierarchyName != "Region" || strings.Join(got.Elements, ",") != "North,South" {
+		t.Errorf("row subset = %+v", got)
+	}
+	if got := view.Titles[0]; got.Selected.Name != "Actual" || got.Selected.DimensionName != "Version" || strings.Join(got.Subset.Elements, ",") != "Actual" {
+		t.Errorf("title = %+v %+v", got.Subset, got.Selected)
+	}
+
+	mdx, err := view.ToMDX()
+	if err != nil {
+		t.Fatalf("ToMDX() failed: %v", err)
+	}
+	want := "SELECT NON EMPTY {TM1SUBSETALL([Period].[Period])} ON COLUMNS, NON EMPTY {[Region].[Region].[North], [Region].[Region].[South]} ON ROWS FROM [Sales] WHERE ([Version].[Version].[Actual])"
+	if mdx != want {
+		t.Errorf("ToMDX() = %s, want %s", mdx, want)
+	}
+}
+
+func TestNativeViewBuilderBuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *NativeViewBuilder
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			builder: NewNativeViewBuilder("Sales").Columns("Period", nil),
+			wantErr: "requires a name",
+		},
+		{
+			name:    "missing columns",
+			builder: NewNativeViewBuilder("Sales").Name("v").Rows("Region", nil),
+			wantErr: "at least one column",
+		},
+		{
+			name:    "duplicate dimension",
+			builder: NewNativeViewBuilder("Sales").Name("v").Columns("Region", nil).Title("region", nil, "North"),
+			wantErr: "more than once",
+		},
+		{
+			name:    "subset of other dimension",
+			builder: NewNativeViewBuilder("Sales").Name("v").Columns("Period", models.NewSubset("Region", "", "Top")),
+			wantErr: "belongs to dimension",
+		},
+		{
+			name:    "missing dimension",
+			builder: NewNativeViewBuilder("Sales").Name("v").Columns("", nil),
+			wantErr: "requires a dimension",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Build() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNativeViewBuilderCreate(t *testing.T) {
+	var requests []string
+	var subsetBody, viewBody map[string]interface{}
+	service := newTestViewService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/Cubes('Sales')/Dimensions"):
+			w.Write([]byte(`{"value":[{"Name":"Period"},{"Name":"Region"},{"Name":"Version"}]}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && strings.Contains(r.URL.Path, "/PrivateSubsets"):
+			json.NewDecoder(r.Body).Decode(&subsetBody)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&viewBody)
+			w.WriteHeader(http.StatusCreated)
+		}
+	})
+
+	topRegions := models.NewStaticSubset("Region", "", "Top Regions", []string{"North"})
+	view, err := NewNativeViewBuilder("Sales").
+		Name("Revenue").
+		Columns("Period", nil).
+		Rows("Region", topRegions).
+		CreateSubsets(true).
+		Create(context.Background(), service.rest, true)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	want := []string{
+		"GET /Cubes('Sales')/Dimensions",
+		"GET /Dimensions('Region')/Hierarchies('Region')/PrivateSubsets('Top Regions')",
+		"POST /Dimensions('Region')/Hierarchies('Region')/PrivateSubsets",
+		"POST /Cubes('Sales')/PrivateViews",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+	if subsetBody["Name"] != "Top Regions" {
+		t.Errorf("subset body = %v", subsetBody)
+	}
+	rows := viewBody["Rows"].([]interface{})
+	if bind := rows[0].(map[string]interface{})["Subset@odata.bind"]; bind != "Dimensions('Region')/Hierarchies('Region')/PrivateSubsets('Top%20Regions')" {
+		t.Errorf("row subset binding = %v", bind)
+	}
+	if !view.Rows[0].Subset.Private {
+		t.Error("view does not refer to the private subset")
+	}
+
+	if _, err := NewNativeViewBuilder("Sales").Name("Revenue").Columns("Period", nil).Rows("Region", topRegions).
+		CreateSubsets(true).Create(context.Background(), service.rest, false); err == nil || !strings.Contains(err.Error(), "private subsets") {
+		t.Errorf("Create() of public view with private subsets error = %v", err)
+	}
+
+	requests = nil
+	_, err = NewNativeViewBuilder("Sales").Name("Revenue").Columns("Product", nil).Create(context.Background(), service.rest, false)
+	if err == nil || !strings.Contains(err.Error(), "Product") {
+		t.Errorf("Create() with unknown dimension error = %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("requests after failed validation = %v", requests)
+	}
+}
@@ -5,12 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/url"
 	"strings"
 
 	"github.com/andreyea/tm1go/pkg/models"
 )
 
+// viewExpand expands the axis subsets of native views so that the returned
+// definitions can be written back to the server.
+const viewExpand = "tm1.NativeView/Rows/Subset($expand=Hierarchy($select=Name;" +
+	"$expand=Dimension($select=Name)),Elements($select=Name);" +
+	"$select=Expression,UniqueName,Name,Alias)," +
+	"tm1.NativeView/Columns/Subset($expand=Hierarchy($select=Name;" +
+	"$expand=Dimension($select=Name)),Elements($select=Name);" +
+	"$select=Expression,UniqueName,Name,Alias)," +
+	"tm1.NativeView/Titles/Subset($expand=Hierarchy($select=Name;" +
+	"$expand=Dimension($select=Name)),Elements($select=Name);" +
+	"$select=Expression,UniqueName,Name,Alias)," +
+	"tm1.NativeView/Titles/Selected($select=Name;$expand=Hierarchy($select=Name;$expand=Dimension($select=Name)))"
+
 // ViewService handles operations for TM1 Views
 // Supports both public and private views.
 type ViewService struct {
@@ -29,18 +41,7 @@ func (vs *ViewService) GetAll(ctx context.Context, cubeName string, privateViews
 		viewType = "PrivateViews"
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/%s?$expand="+
-		"tm1.NativeView/Rows/Subset($expand=Hierarchy($select=Name;"+
-		"$expand=Dimension($select=Name)),Elements($select=Name);"+
-		"$select=Expression,UniqueName,Name,Alias),"+
-		"tm1.NativeView/Columns/Subset($expand=Hierarchy($select=Name;"+
-		"$expand=Dimension($select=Name)),Elements($select=Name);"+
-		"$select=Expression,UniqueName,Name,Alias),"+
-		"tm1.NativeView/Titles/Subset($expand=Hierarchy($select=Name;"+
-		"$expand=Dimension($select=Name)),Elements($select=Name);"+
-		"$select=Expression,UniqueName,Name,Alias),"+
-		"tm1.NativeView/Titles/Selected($select=Name;$expand=Hierarchy($select=Name;$expand=Dimension($select=Name)))",
-		url.PathEscape(cubeName), viewType)
+	endpoint := fmt.Sprintf("/Cubes('%s')/%s?$expand=%s", escapeODataKey(cubeName), viewType, viewExpand)
 
 	var result struct {
 		Value []models.ViewWrapper `json:"value"`
@@ -60,12 +61,21 @@ func (vs *ViewService) GetAll(ctx context.Context, cubeName string, privateViews
 
 // Get retrieves a view by name
 func (vs *ViewService) Get(ctx context.Context, cubeName, viewName string, private bool) (models.ViewDefinition, error) {
+	return vs.get(ctx, cubeName, viewName, private, "*")
+}
+
+// getDefinition retrieves a view with its axis subsets expanded, so that it can be written back.
+func (vs *ViewService) getDefinition(ctx context.Context, cubeName, viewName string, private bool) (models.ViewDefinition, error) {
+	return vs.get(ctx, cubeName, viewName, private, viewExpand)
+}
+
+func (vs *ViewService) get(ctx context.Context, cubeName, viewName string, private bool, expand string) (models.ViewDefinition, error) {
 	viewType := "Views"
 	if private {
 		viewType = "PrivateViews"
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')?$expand=*", url.PathEscape(cubeName), viewType, url.PathEscape(viewName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')?$expand=%s", escapeODataKey(cubeName), viewType, escapeODataKey(viewName), expand)
 	wrapper := models.ViewWrapper{}
 	if err := vs.rest.JSON(ctx, "GET", endpoint, nil, &wrapper); err != nil {
 		return nil, err
@@ -81,7 +91,7 @@ func (vs *ViewService) Delete(ctx context.Context, cubeName, viewName string, pr
 		viewType = "PrivateViews"
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')", url.PathEscape(cubeName), viewType, url.PathEscape(viewName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')", escapeODataKey(cubeName), viewType, escapeODataKey(viewName))
 	resp, err := vs.rest.Delete(ctx, endpoint)
 	if err != nil {
 		return err
@@ -98,7 +108,7 @@ func (vs *ViewService) Execute(ctx context.Context, cubeName, viewName string, p
 		viewType = "PrivateViews"
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')/tm1.Execute", url.PathEscape(cubeName), viewType, url.PathEscape(viewName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')/tm1.Execute", escapeODataKey(cubeName), viewType, escapeODataKey(viewName))
 	resp, err := vs.rest.Post(ctx, endpoint, strings.NewReader(""))
 	if err != nil {
 		return "", err
@@ -120,7 +130,7 @@ func (vs *ViewService) Exists(ctx context.Context, cubeName, viewName string, pr
 		viewType = "PrivateViews"
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')", url.PathEscape(cubeName), viewType, url.PathEscape(viewName))
+	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')", escapeODataKey(cubeName), viewType, escapeODataKey(viewName))
 	resp, err := vs.rest.Get(ctx, endpoint)
 	if err != nil {
 		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
@@ -140,7 +150,7 @@ func (vs *ViewService) Create(ctx context.Context, cubeName string, view models.
 		viewType = "PrivateViews"
 	}
 
-	endpoint := fmt.Sprintf("/Cubes('%s')/%s", url.PathEscape(cubeName), viewType)
+	endpoint := fmt.Sprintf("/Cubes('%s')/%s", escapeODataKey(cubeName), viewType)
 	viewBody, err := view.Body(true)
 	if err != nil {
 		return err
@@ -154,3 +164,351 @@ func (vs *ViewService) Create(ctx context.Context, cubeName string, view models.
 	_, err = io.Copy(io.Discard, resp.Body)
 	return err
 }
+
+// GetAllNames retrieves the names of all public or private views in a cube
+func (vs *ViewService) GetAllNames(ctx context.Context, cubeName string, private bool) ([]string, error) {
+	viewType := "Views"
+	if private {
+		viewType = "PrivateViews"
+	}
+
+	endpoint := fmt.Sprintf("/Cubes('%s')/%s?$select=Name", escapeODataKey(cubeName), viewType)
+
+	var result struct {
+		Value []struct {
+			Name string `json:"Name"`
+		} `json:"value"`
+	}
+
+	if err := vs.rest.JSON(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(result.Value))
+	for i, view := range result.Value {
+		names[i] = view.Name
+	}
+
+	return names, nil
+}
+
+// Update updates an existing view
+func (vs *ViewService) Update(ctx context.Context, cubeName string, view models.ViewDefinition, private bool) error {
+	viewType := "Views"
+	if private {
+		viewType = "PrivateViews"
+	}
+
+	endpoint := fmt.Sprintf("/Cubes('%s')/%s('%s')", escapeODataKey(cubeName), viewType, escapeODataKey(view.GetName()))
+	viewBody, err := view.Body(true)
+	if err != nil {
+		return err
+	}
+
+	resp, err := vs.rest.Patch(ctx, endpoint, strings.NewReader(viewBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// UpdateOrCreate updates the view if it exists, otherwise creates it
+func (vs *ViewService) UpdateOrCreate(ctx context.Context, cubeName string, view models.ViewDefinition, private bool) error {
+	exists, err := vs.Exists(ctx, cubeName, view.GetName(), private)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return vs.Update(ctx, cubeName, view, private)
+	}
+
+	return vs.Create(ctx, cubeName, view, private)
+}
+
+// Rename renames a view. TM1 has no rename operation for views, so the view is
+// created under the new name before the original is deleted.
+func (vs *ViewService) Rename(ctx context.Context, cubeName, viewName, newViewName string, private bool) error {
+	view, err := vs.getDefinition(ctx, cubeName, viewName, private)
+	if err != nil {
+		return err
+	}
+
+	renamed, err := copyViewDefinition(view, newViewName)
+	if err != nil {
+		return err
+	}
+
+	if err := vs.Create(ctx, cubeName, renamed, private); err != nil {
+		return fmt.Errorf("create view '%s': %w", newViewName, err)
+	}
+
+	return vs.Delete(ctx, cubeName, viewName, private)
+}
+
+// CopyTo copies a view to another cube under the same name, updating the view there
+// if it already exists. Subsets of native views are bound to the dimensions of the
+// target cube, which must contain every dimension used by the view; the FROM clause
+// of MDX views is pointed to the target cube.
+func (vs *ViewService) CopyTo(ctx context.Context, cubeName, viewName, targetCubeName string, private bool) error {
+	view, err := vs.getDefinition(ctx, cubeName, viewName, private)
+	if err != nil {
+		return err
+	}
+
+	copied, err := copyViewDefinition(view, viewName)
+	if err != nil {
+		return err
+	}
+
+	switch v := copied.(type) {
+	case *models.NativeView:
+		dimensionNames, err := NewCubeService(vs.rest).GetDimensionNames(ctx, targetCubeName)
+		if err != nil {
+			return err
+		}
+		if err := checkViewDimensions(v, targetCubeName, dimensionNames); err != nil {
+			return err
+		}
+	case *models.MDXView:
+		mdx, err := replaceMDXCube(v.MDX, targetCubeName)
+		if err != nil {
+			return fmt.Errorf("view '%s': %w", viewName, err)
+		}
+		v.MDX = mdx
+	}
+
+	return vs.UpdateOrCreate(ctx, targetCubeName, copied, private)
+}
+
+// ConvertToMDXView creates an MDX view from the MDX equivalent of a native view.
+// If mdxViewName is empty or equal to viewName the native view is replaced; it is
+// restored when the MDX view cannot be created.
+func (vs *ViewService) ConvertToMDXView(ctx context.Context, cubeName, viewName, mdxViewName string, private bool) (*models.MDXView, error) {
+	view, err := vs.getDefinition(ctx, cubeName, viewName, private)
+	if err != nil {
+		return nil, err
+	}
+
+	nativeView, ok := view.(*models.NativeView)
+	if !ok {
+		return nil, fmt.Errorf("view '%s' is not a native view", viewName)
+	}
+
+	if nativeView.Cube == nil {
+		nativeView.Cube = &models.Cube{Name: cubeName}
+	}
+	mdx, err := nativeView.ToMDX()
+	if err != nil {
+		return nil, err
+	}
+
+	if mdxViewName == "" {
+		mdxViewName = viewName
+	}
+	mdxView := &models.MDXView{
+		Type: "#ibm.tm1.api.v1.MDXView",
+		Name: mdxViewName,
+		MDX:  mdx,
+	}
+
+	if mdxViewName != viewName {
+		if err := vs.Create(ctx, cubeName, mdxView, private); err != nil {
+			return nil, err
+		}
+		return mdxView, nil
+	}
+
+	if err := vs.Delete(ctx, cubeName, viewName, private); err != nil {
+		return nil, err
+	}
+	if err := vs.Create(ctx, cubeName, mdxView, private); err != nil {
+		if restoreErr := vs.Create(ctx, cubeName, nativeView, private); restoreErr != nil {
+			return nil, fmt.Errorf("create MDX view: %w (restore native view: %v)", err, restoreErr)
+		}
+		return nil, fmt.Errorf("create MDX view: %w", err)
+	}
+
+	return mdxView, nil
+}
+
+// copyViewDefinition returns a copy of the view with a new name, detached from its cube.
+func copyViewDefinition(view models.ViewDefinition, name string) (models.ViewDefinition, error) {
+	switch v := view.(type) {
+	case *models.NativeView:
+		copied := *v
+		copied.Name = name
+		copied.Cube = nil
+		return &copied, nil
+	case *models.MDXView:
+		copied := *v
+		copied.Name = name
+		copied.Cube = nil
+		return &copied, nil
+	default:
+		return nil, fmt.Errorf("unsupported view type %T", view)
+	}
+}
+
+// checkViewDimensions verifies that the cube contains every dimension referenced by the view.
+func checkViewDimensions(view *models.NativeView, cubeName string, dimensionNames []string) error {
+	available := make(map[string]bool, len(dimensionNames))
+	for _, name := range dimensionNames {
+		available[strings.ToLower(strings.ReplaceAll(name, " ", ""))] = true
+	}
+
+	var subsets []*models.Subset
+	for _, axis := range view.Columns {
+		subsets = append(subsets, axis.Subset)
+	}
+	for _, axis := range view.Rows {
+		subsets = append(subsets, axis.Subset)
+	}
+	for _, axis := range view.Titles {
+		subsets = append(subsets, axis.Subset)
+	}
+
+	var missing []string
+	for _, subset := range subsets {
+		if subset == nil {
+			continue
+		}
+		if !available[strings.ToLower(strings.ReplaceAll(subset.DimensionName, " ", ""))] {
+			missing = append(missing, subset.DimensionName)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("cube '%s' does not contain dimensions used by view '%s': %s",
+			cubeName, view.Name, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// replaceMDXCube replaces the cube of the top-level FROM clause. When that clause holds a
+// sub-select, the cube of the sub-select's FROM is replaced instead. FROM inside identifiers,
+// string literals and comments is ignored.
+func replaceMDXCube(mdx, cubeName string) (string, error) {
+	depth := 0
+	for _, from := range mdxFromKeywords(mdx) {
+		if from.depth != depth {
+			continue
+		}
+		start := from.end
+		for start < len(mdx) && isMDXSpace(mdx[start]) {
+			start++
+		}
+		if start < len(mdx) && mdx[start] == '(' {
+			depth++
+			continue
+		}
+		end := mdxIdentifierEnd(mdx, start)
+		if end == start {
+			return "", fmt.Errorf("MDX FROM clause has no cube")
+		}
+		return mdx[:start] + models.QuoteMDXName(cubeName) + mdx[end:], nil
+	}
+	return "", fmt.Errorf("MDX has no FROM clause")
+}
+
+// mdxKeyword is the position of a keyword in an MDX query and its parenthesis depth.
+type mdxKeyword struct {
+	end   int // Offset just past the keyword
+	depth int
+}
+
+// mdxFromKeywords returns the FROM keywords of an MDX query, skipping bracketed identifiers,
+// string literals and comments.
+func mdxFromKeywords(mdx string) []mdxKeyword {
+	var keywords []mdxKeyword
+	depth := 0
+	for i := 0; i < len(mdx); {
+		switch c := mdx[i]; {
+		case c == '[':
+			i = mdxIdentifierEnd(mdx, i)
+		case c == '"' || c == '\'':
+			i = mdxStringEnd(mdx, i)
+		case strings.HasPrefix(mdx[i:], "--") || strings.HasPrefix(mdx[i:], "//"):
+			if n := strings.IndexByte(mdx[i:], '\n'); n >= 0 {
+				i += n + 1
+			} else {
+				i = len(mdx)
+			}
+		case strings.HasPrefix(mdx[i:], "/*"):
+			if n := strings.Index(mdx[i+2:], "*/"); n >= 0 {
+				i += n + 4
+			} else {
+				i = len(mdx)
+			}
+		case c == '(':
+			depth++
+			i++
+		case c == ')':
+			depth--
+			i++
+		case isMDXWordChar(c):
+			j := i
+			for j < len(mdx) && isMDXWordChar(mdx[j]) {
+				j++
+			}
+			if strings.EqualFold(mdx[i:j], "FROM") {
+				keywords = append(keywords, mdxKeyword{end: j, depth: depth})
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	return keywords
+}
+
+// mdxIdentifierEnd returns the offset just past the identifier starting at start: a bracketed
+// name, with ]] as an escaped bracket, or a plain name, possibly qualified with dots. It returns
+// start if there is no identifier.
+func mdxIdentifierEnd(mdx string, start int) int {
+	if start < len(mdx) && mdx[start] == '[' {
+		for i := start + 1; i < len(mdx); i++ {
+			if mdx[i] != ']' {
+				continue
+			}
+			if i+1 < len(mdx) && mdx[i+1] == ']' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+		return len(mdx)
+	}
+	i := start
+	for i < len(mdx) && (isMDXWordChar(mdx[i]) || (i > start && mdx[i] == '.')) {
+		i++
+	}
+	return i
+}
+
+// mdxStringEnd returns the offset just past the string literal starting at start, where a
+// doubled quote is an escaped quote.
+func mdxStringEnd(mdx string, start int) int {
+	quote := mdx[start]
+	for i := start + 1; i < len(mdx); i++ {
+		if mdx[i] != quote {
+			continue
+		}
+		if i+1 < len(mdx) && mdx[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(mdx)
+}
+
+func isMDXWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isMDXSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
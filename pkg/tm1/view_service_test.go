@@ -0,0 +1,449 @@
+package tm1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andreyea/tm1go/pkg/models"
+)
+
+const testNativeViewJSON = `{
+	"@odata.type": "#ibm.tm1.api.v1.NativeView",
+	"Name": "Default",
+	"SuppressEmptyRows": true,
+	"Columns": [{"Subset": {"Name": "", "Expression": "{[Period].[Period].Members}",
+		"Hierarchy": {"Name": "Period", "Dimension": {"Name": "Period"}}}}],
+	"Rows": [{"Subset": {"@odata.id": "Dimensions('Region')/Hierarchies('Region')/PrivateSubsets('Top%20Regions')",
+		"Name": "Top Regions", "Expression": "{[Region].[Region].[World]}",
+		"Hierarchy": {"Name": "Region", "Dimension": {"Name": "Region"}}}}],
+	"Titles": [{"Subset": {"Name": "", "Elements": [{"Name": "Actual"}],
+		"Hierarchy": {"Name": "Version", "Dimension": {"Name": "Version"}}},
+		"Selected": {"Name": "Actual", "Hierarchy": {"Name": "Version", "Dimension": {"Name": "Version"}}}}]
+}`
+
+func newTestViewService(t *testing.T, handler http.HandlerFunc) *ViewService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	rest, err := NewRestService(Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewRestService() failed: %v", err)
+	}
+	return NewViewService(rest)
+}
+
+func TestViewServiceGetAllNames(t *testing.T) {
+	service := newTestViewService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/Cubes('Sales')/Views"):
+			w.Write([]byte(`{"value":[{"Name":"Default"},{"Name":"Input"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/Cubes('Sales')/PrivateViews"):
+			w.Write([]byte(`{"value":[{"Name":"Mine"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	public, err := service.GetAllNames(context.Background(), "Sales", false)
+	if err != nil {
+		t.Fatalf("GetAllNames() failed: %v", err)
+	}
+	if strings.Join(public, ",") != "Default,Input" {
+		t.Errorf("public views = %v", public)
+	}
+
+	private, err := service.GetAllNames(context.Background(), "Sales", true)
+	if err != nil {
+		t.Fatalf("GetAllNames() failed: %v", err)
+	}
+	if len(private) != 1 || private[0] != "Mine" {
+		t.Errorf("private views = %v", private)
+	}
+}
+
+func TestViewServiceUpdateOrCreate(t *testing.T) {
+	var requests []string
+	service := newTestViewService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			if !strings.HasSuffix(r.URL.Path, "/Views('Default')") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{}`))
+		case http.MethodPatch, http.MethodPost:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["MDX"] != "SELECT {[Period].[Period].Members} ON 0 FROM [Sales]" {
+				t.Errorf("MDX = %v", body["MDX"])
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	view := &models.MDXView{Name: "Default", MDX: "SELECT {[Period].[Period].Members} ON 0 FROM [Sales]"}
+	if err := service.UpdateOrCreate(ctx, "Sales", view, false); err != nil {
+		t.Fatalf("UpdateOrCreate() failed: %v", err)
+	}
+	view.Name = "New"
+	if err := service.UpdateOrCreate(ctx, "Sales", view, false); err != nil {
+		t.Fatalf("UpdateOrCreate() failed: %v", err)
+	}
+
+	want := []string{
+		"GET /Cubes('Sales')/Views('Default')",
+		"PATCH /Cubes('Sales')/Views('Default')",
+		"GET /Cubes('Sales')/Views('New')",
+		"POST /Cubes('Sales')/Views",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestViewServiceRename(t *testing.T) {
+	var requests []string
+	var created map[string]interface{}
+	createStatus := http.StatusCreated
+	service := newTestViewService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(testNativeViewJSON))
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(createStatus)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	if err := service.Rename(context.Background(), "Sales", "Default", "Default Copy", true); err != nil {
+		t.Fatalf("Rename() failed: %v", err)
+	}
+	want := []string{
+		"GET /Cubes('Sales')/PrivateViews('Default')",
+		"POST /Cubes('Sales')/PrivateViews",
+		"DELETE /Cubes('Sales')/PrivateViews('Default')",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+	if created["Name"] != "Default Copy" {
+		t.Errorf("created view name = %v", created["Name"])
+	}
+
+	// The original view is kept when the new one cannot be created
+	requests = nil
+	createStatus = http.StatusBadRequest
+	if err := service.Rename(context.Background(), "Sales", "Default", "Default Copy", true); err == nil {
+		t.Fatal("Rename() succeeded although create failed")
+	}
+	for _, request := range requests {
+		if strings.HasPrefix(request, http.MethodDelete) {
+			t.Errorf("view deleted after failed create: %s", request)
+		}
+	}
+}
+
+func TestNativeViewBodySubsetBinding(t *testing.T) {
+	named := models.NewStaticSubset("Region", "", "Top Regions", []string{"North"})
+	stored := models.NewSubset("Region", "", "Mine")
+	stored.Bind, stored.Private = true, true
+	view := &models.NativeView{
+		Name:    "Report",
+		Columns: []models.ViewAxis{{Subset: named}},
+		Rows:    []models.ViewAxis{{Subset: stored}},
+	}
+
+	body, err := view.Body(true)
+	if err != nil {
+		t.Fatalf("Body() failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	json.Unmarshal([]byte(body), &decoded)
+
+	column := decoded["Columns"].([]interface{})[0].(map[string]interface{})
+	subset, ok := column["Subset"].(map[string]interface{})
+	if !ok || subset["Name"] != "Top Regions" || column["Subset@odata.bind"] != nil {
+		t.Errorf("named subset not embedded: %v", column)
+	}
+	row := decoded["Rows"].([]interface{})[0].(map[string]interface{})
+	if bind := row["Subset@odata.bind"]; bind != "Dimensions('Region')/Hierarchies('Region')/PrivateSubsets('Mine')" {
+		t.Errorf("row subset binding = %v", bind)
+	}
+}
+
+func TestViewServiceGetExpandsAll(t *testing.T) {
+	service := newTestViewService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$expand") != "*" {
+			t.Errorf("$expand = %s", r.URL.Query().Get("$expand"))
+		}
+		w.Write([]byte(testNativeViewJSON))
+	})
+	if _, err := service.Get(context.Background(), "Sales", "Default", false); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+}
+
+func TestViewServiceCopyToNativeView(t *testing.T) {
+	var created map[string]interface{}
+	targetDimensions := `{"value":[{"Name":"Version"},{"Name":"Region"},{"Name":"period"}]}`
+	service := newTestViewService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/Cubes('Sales')/Views('Default')"):
+			if !strings.Contains(r.URL.RawQuery, "Subset") {
+				t.Errorf("view requested without subsets: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(testNativeViewJSON))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/Cubes('Sales Plan')/Dimensions"):
+			w.Write([]byte(targetDimensions))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/Cubes('Sales Plan')/Views('Default')"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/Cubes('Sales Plan')/Views"):
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	if err := service.CopyTo(context.Background(), "Sales", "Default", "Sales Plan", false); err != nil {
+		t.Fatalf("CopyTo() failed: %v", err)
+	}
+
+	if created["Name"] != "Default" || created["SuppressEmptyRows"] != true {
+		t.Errorf("created view = %v", created)
+	}
+	rows := created["Rows"].([]interface{})
+	if bind := rows[0].(map[string]interface{})["Subset@odata.bind"]; bind != "Dimensions('Region')/Hierarchies('Region')/PrivateSubsets('Top%20Regions')" {
+		t.Errorf("row subset binding = %v", bind)
+	}
+	columns := created["Columns"].([]interface{})
+	subset := columns[0].(map[string]interface{})["Subset"].(map[string]interface{})
+	if subset["Hierarchy@odata.bind"] != "Dimensions('Period')/Hierarchies('Period')" || subset["Expression"] != "{[Period].[Period].Members}" {
+		t.Errorf("column subset = %v", subset)
+	}
+	titles := created["Titles"].([]interface{})
+	if selected := titles[0].(map[string]interface{})["Selected@odata.bind"]; selected != "Dimensions('Version')/Hierarchies('Version')/Elements('Actual')" {
+		t.Errorf("title selection = %v", selected)
+	}
+
+	created = nil
+	targetDimensions = `{"value":[{"Name":"Version"},{"Name":"Period"}]}`
+	err := service.CopyTo(context.Background(), "Sales", "Default", "Sales Plan", false)
+	if err == nil || !strings.Contains(err.Error(), "Region") {
+		t.Fatalf("CopyTo() error = %v, want missing dimension Region", err)
+	}
+	if created != nil {
+		t.Error("view created in cube without the required dimensions")
+	}
+}
+
+func TestViewServiceCopyToMDXView(t *testing.T) {
+	var patched map[string]interface{}
+	service := newTestViewService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/Cubes('Sales')/"):
+			io.WriteString(w, `{"@odata.type":"#ibm.tm1.api.v1.MDXView","Name":"Report",
+				"MDX":"SELECT {[Period].[Period].Members} ON 0 FROM (SELECT {[Region].[Region].[World]} ON 0 FROM [Sales]) WHERE ([Version].[Version].[Actual])"}`)
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/Cubes('Sales]Plan')/Views('Report')"):
+			json.NewDecoder(r.Body).Decode(&patched)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	if err := service.CopyTo(context.Background(), "Sales", "Report", "Sales]Plan", false); err != nil {
+		t.Fatalf("CopyTo() failed: %v", err)
+	}
+	want := "SELECT {[Period].[Period].Members} ON 0 FROM (SELECT {[Region].[Region].[World]} ON 0 FROM [Sales]]Plan]) WHERE ([Version].[Version].[Actual])"
+	if patched["MDX"] != want {
+		t.Errorf("MDX = %v, want %s", patched["MDX"], want)
+	}
+}
+
+func TestReplaceMDXCube(t *testing.T) {
+	tests := []struct {
+		name    string
+		mdx     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "from in member name",
+			mdx:  "SELECT {[Period].[Period].Members} ON 0 FROM [Sales] WHERE ([Version].[Version].[Copy from Actual])",
+			want: "SELECT {[Period].[Period].Members} ON 0 FROM [Plan] WHERE ([Version].[Version].[Copy from Actual])",
+		},
+		{
+			name: "from in string literal and comment",
+			mdx:  "WITH MEMBER [Measure].[Note] AS 'from [Other]' SELECT {[Measure].[Note]} ON 0 /* from [Old] */ FROM Sales -- from [X]",
+			want: "WITH MEMBER [Measure].[Note] AS 'from [Other]' SELECT {[Measure].[Note]} ON 0 /* from [Old] */ FROM [Plan] -- from [X]",
+		},
+		{
+			name: "sub-select",
+			mdx:  "SELECT {[A].[A].[x from y]} ON 0 FROM (SELECT {[A].[A].[z]} ON 0 FROM [Sales]]Old]) WHERE ([B].[B].[from])",
+			want: "SELECT {[A].[A].[x from y]} ON 0 FROM (SELECT {[A].[A].[z]} ON 0 FROM [Plan]) WHERE ([B].[B].[from])",
+		},
+		{
+			name:    "no from clause",
+			mdx:     "SELECT {[Version].[Version].[Copy from Actual]} ON 0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replaceMDXCube(tt.mdx, "Plan")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replaceMDXCube() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("replaceMDXCube() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNativeViewToMDX(t *testing.T) {
+	region := models.NewStaticSubset("Region", "Region", "", []string{"North", "South]East"})
+	period := models.NewDynamicSubset("Period", "Period", "", "{TM1FILTERBYLEVEL({TM1SUBSETALL([Period].[Period])}, 0)}")
+	version := models.NewStaticSubset("Version", "Version", "", []string{"Actual", "Budget"})
+	named := models.NewSubset("Product", "Product", "Top \"10\"")
+
+	tests := []struct {
+		name    string
+		view    models.NativeView
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "rows, columns and titles",
+			view: models.NativeView{
+				Name:              "Default",
+				Columns:           []models.ViewAxis{{Subset: period}},
+				Rows:              []models.ViewAxis{{Subset: region}},
+				Titles:            []models.ViewTitleAxis{{Subset: version, Selected: &models.ViewSelectedElement{Name: "Budget", DimensionName: "Version", HierarchyName: "Version"}}},
+				SuppressEmptyRows: true,
+			},
+			want: "SELECT {TM1FILTERBYLEVEL({TM1SUBSETALL([Period].[Period])}, 0)} ON COLUMNS, " +
+				"NON EMPTY {[Region].[Region].[North], [Region].[Region].[South]]East]} ON ROWS " +
+				"FROM [Sales] WHERE ([Version].[Version].[Budget])",
+		},
+		{
+			name: "cross joined columns and default title",
+			view: models.NativeView{
+				Name:                 "Default",
+				Columns:              []models.ViewAxis{{Subset: region}, {Subset: named}},
+				Titles:               []models.ViewTitleAxis{{Subset: version}, {Subset: period}},
+				SuppressEmptyColumns: true,
+			},
+			want: "SELECT NON EMPTY {{[Region].[Region].[North], [Region].[Region].[South]]East]} * " +
+				"{TM1SUBSETTOSET([Product].[Product],\"Top \"\"10\"\"\")}} ON COLUMNS " +
+				"FROM [Sales] WHERE ([Version].[Version].[Actual])",
+		},
+		{
+			name:    "no columns",
+			view:    models.NativeView{Name: "Default", Rows: []models.ViewAxis{{Subset: region}}},
+			wantErr: true,
+		},
+		{
+			name:    "empty anonymous subset",
+			view:    models.NativeView{Name: "Default", Columns: []models.ViewAxis{{Subset: models.NewSubset("Region", "", "")}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.view.Cube = &models.Cube{Name: "Sales"}
+			got, err := tt.view.ToMDX()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ToMDX() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToMDX() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ToMDX() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (&models.NativeView{Name: "Default", Columns: []models.ViewAxis{{Subset: region}}}).ToMDX(); err == nil {
+		t.Error("ToMDX() without cube succeeded")
+	}
+}
+
+func TestViewServiceConvertToMDXView(t *testing.T) {
+	const wantMDX = "SELECT {[Period].[Period].Members} ON COLUMNS, NON EMPTY {TM1SUBSETTOSET([Region].[Region],\"Top Regions\")} ON ROWS " +
+		"FROM [Sales] WHERE ([Version].[Version].[Actual])"
+
+	var requests []string
+	var bodies []map[string]interface{}
+	failMDXCreate := false
+	service := newTestViewService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			// A named subset is returned without its definition
+			w.Write([]byte(strings.Replace(testNativeViewJSON, `"Expression": "{[Region].[Region].[World]}",`, "", 1)))
+		case http.MethodPost:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			bodies = append(bodies, body)
+			if failMDXCreate && body["MDX"] != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	view, err := service.ConvertToMDXView(ctx, "Sales", "Default", "Default MDX", false)
+	if err != nil {
+		t.Fatalf("ConvertToMDXView() failed: %v", err)
+	}
+	if view.Name != "Default MDX" || view.MDX != wantMDX {
+		t.Errorf("ConvertToMDXView() = %s: %s", view.Name, view.MDX)
+	}
+	if len(requests) != 2 || bodies[0]["MDX"] != wantMDX || bodies[0]["@odata.type"] != "#ibm.tm1.api.v1.MDXView" {
+		t.Errorf("requests = %v, bodies = %v", requests, bodies)
+	}
+
+	// Replacing the native view restores it when the MDX view cannot be created
+	requests, bodies = nil, nil
+	failMDXCreate = true
+	if _, err := service.ConvertToMDXView(ctx, "Sales", "Default", "", false); err == nil {
+		t.Fatal("ConvertToMDXView() succeeded although create failed")
+	}
+	want := []string{
+		"GET /Cubes('Sales')/Views('Default')",
+		"DELETE /Cubes('Sales')/Views('Default')",
+		"POST /Cubes('Sales')/Views",
+		"POST /Cubes('Sales')/Views",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+	if len(bodies) == 2 && bodies[1]["@odata.type"] != "#ibm.tm1.api.v1.NativeView" {
+		t.Errorf("restored view = %v", bodies[1])
+	}
+}